@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/930r91na/Subasta-grpc/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Config holds the admin CLI's settings; see package config for how they
+// are loaded
+type Config struct {
+	Network networkConfig `yaml:"network"`
+	TLS     tlsConfig     `yaml:"tls"`
+	Token   string        `yaml:"token" flag:"token" env:"AUCTION_ADMIN_TOKEN" secret:"true" usage:"admin token"`
+}
+
+// networkConfig is the server the CLI calls
+type networkConfig struct {
+	Server  string        `yaml:"server" flag:"server" usage:"address of the auction server's gRPC API"`
+	Timeout time.Duration `yaml:"timeout" flag:"timeout" usage:"how long the command may take"`
+}
+
+// tlsConfig secures the connection to the server
+type tlsConfig struct {
+	CA   string `yaml:"ca" flag:"tls-ca" usage:"PEM CA certificates to verify the server against (plaintext when empty)"`
+	Cert string `yaml:"cert" flag:"tls-cert" usage:"PEM client certificate, for servers that require one"`
+	Key  string `yaml:"key" flag:"tls-key" usage:"PEM private key of the client certificate"`
+}
+
+// Validate reports every setting that is out of range or unreadable
+func (c *Config) Validate() error {
+	var errs config.Errors
+	if err := config.CheckAddr(c.Network.Server); err != nil {
+		errs.Addf("network.server", "%v", err)
+	}
+	if c.Network.Timeout <= 0 {
+		errs.Addf("network.timeout", "must be positive")
+	}
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		errs.Addf("tls.key", "cert and key must be set together")
+	}
+	if c.TLS.CA == "" && c.TLS.Cert != "" {
+		errs.Addf("tls.ca", "must be set to present a certificate")
+	}
+	return errs.Err()
+}
+
+const usage = `Usage: admin [flags] <command> [args]
+
+Commands:
+  suspend <user> <reason>             suspend a user
+  unsuspend <user> <reason>           reinstate a user
+  remove <product> <reason>           remove a listing
+  freeze <product> <reason>           freeze bidding on a listing
+  unfreeze <product> <reason>         resume bidding on a listing
+  void <product> <buyer> <reason>     void a buyer's bid
+  close <product> <reason>            force-close an auction
+  state                               show users, products and bids
+  audit [limit]                       show the audit log
+  grant <user> <role> <reason>        give a user a role
+  revoke <user> <role> <reason>       take a role away from a user
+  roles <user>                        show a user's roles and permissions
+  webhooks                            list webhook subscriptions
+  webhook-add <url> <secret> [event...]
+  webhook-delete <id>
+  dead-letters                        list failed webhook deliveries
+  replay <dead-letter-id|all>         deliver failed webhooks again
+
+Flags:
+`
+
+func main() {
+	cfg := &Config{}
+	cfg.Network.Server = "localhost:50051"
+	cfg.Network.Timeout = 10 * time.Second
+	printConfig, args, err := config.LoadCommand(cfg, "admin", "AUCTION_ADMIN_", usage, os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if printConfig {
+		config.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if printConfig {
+		return
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "No command given, see -h")
+		os.Exit(2)
+	}
+
+	creds, err := certs.ClientCredentials(cfg.TLS.CA, cfg.TLS.Cert, cfg.TLS.Key)
+	if err != nil {
+		log.Fatalf("Failed to load certificates: %v", err)
+	}
+	conn, err := grpc.NewClient(cfg.Network.Server, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		log.Fatalf("Could not connect: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewAuctionAdminServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Network.Timeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+cfg.Token)
+
+	if err := run(ctx, client, args[0], args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, client pb.AuctionAdminServiceClient, command string, args []string) error {
+	need := func(n int) error {
+		if len(args) < n {
+			return fmt.Errorf("%s needs %d argument(s), see -h", command, n)
+		}
+		return nil
+	}
+	reason := func(from int) string { return strings.Join(args[from:], " ") }
+
+	switch command {
+	case "suspend", "unsuspend":
+		if err := need(2); err != nil {
+			return err
+		}
+		resp, err := client.SuspendUser(ctx, &pb.SuspendUserRequest{
+			User:      args[0],
+			Suspended: command == "suspend",
+			Reason:    reason(1),
+		})
+		return report(resp, err)
+
+	case "remove":
+		if err := need(2); err != nil {
+			return err
+		}
+		resp, err := client.RemoveProduct(ctx, &pb.RemoveProductRequest{Product: args[0], Reason: reason(1)})
+		return report(resp, err)
+
+	case "freeze", "unfreeze":
+		if err := need(2); err != nil {
+			return err
+		}
+		resp, err := client.FreezeProduct(ctx, &pb.FreezeProductRequest{
+			Product: args[0],
+			Frozen:  command == "freeze",
+			Reason:  reason(1),
+		})
+		return report(resp, err)
+
+	case "void":
+		if err := need(3); err != nil {
+			return err
+		}
+		resp, err := client.VoidBid(ctx, &pb.VoidBidRequest{Product: args[0], Buyer: args[1], Reason: reason(2)})
+		if err == nil && resp.Success {
+			fmt.Printf("Current price: $%.2f (highest bidder: %q)\n", resp.CurrentPrice, resp.HighestBidder)
+		}
+		return report(resp, err)
+
+	case "close":
+		if err := need(2); err != nil {
+			return err
+		}
+		resp, err := client.ForceCloseAuction(ctx, &pb.ForceCloseAuctionRequest{Product: args[0], Reason: reason(1)})
+		return report(resp, err)
+
+	case "state":
+		resp, err := client.GetServerState(ctx, &pb.GetServerStateRequest{})
+		if err != nil {
+			return err
+		}
+		fmt.Println("=== Users ===")
+		for _, u := range resp.Users {
+			fmt.Printf("- %s (email: %q, roles: %v, suspended: %v)\n", u.Name, u.Email, u.Roles, u.Suspended)
+		}
+		fmt.Println("\n=== Products ===")
+		for _, p := range resp.Products {
+			fmt.Printf("- %s (seller: %s, price: $%.2f, bidder: %q, closed: %v, frozen: %v, watchers: %d)\n",
+				p.Product, p.Seller, p.CurrentPrice, p.HighestBidder, p.Closed, p.Frozen, p.Watchers)
+		}
+		fmt.Println("\n=== Bids ===")
+		for _, b := range resp.Bids {
+			fmt.Printf("- %s bids $%.2f for %s\n", b.Buyer, b.Amount, b.Product)
+		}
+		fmt.Printf("\nWebhooks: %d, dead letters: %d\n", resp.Webhooks, resp.DeadLetters)
+		return nil
+
+	case "audit":
+		limit := 20
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid limit %q", args[0])
+			}
+			limit = n
+		}
+		resp, err := client.ListAuditLog(ctx, &pb.ListAuditLogRequest{Limit: int32(limit)})
+		if err != nil {
+			return err
+		}
+		for _, e := range resp.Entries {
+			fmt.Printf("#%d %s %-10s %s %s (%s)\n", e.Id, e.Time.AsTime().Local().Format(time.DateTime),
+				e.Actor, e.Action, e.Message, e.Reason)
+		}
+		return nil
+
+	case "grant":
+		if err := need(3); err != nil {
+			return err
+		}
+		resp, err := client.GrantRole(ctx, &pb.GrantRoleRequest{User: args[0], Role: args[1], Reason: reason(2)})
+		if err == nil {
+			fmt.Printf("Roles: %v\n", resp.Roles)
+		}
+		return report(resp, err)
+
+	case "revoke":
+		if err := need(3); err != nil {
+			return err
+		}
+		resp, err := client.RevokeRole(ctx, &pb.RevokeRoleRequest{User: args[0], Role: args[1], Reason: reason(2)})
+		if err == nil {
+			fmt.Printf("Roles: %v\n", resp.Roles)
+		}
+		return report(resp, err)
+
+	case "roles":
+		if err := need(1); err != nil {
+			return err
+		}
+		resp, err := client.ListRoles(ctx, &pb.ListRolesRequest{User: args[0]})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Roles: %v\nPermissions: %v\n", resp.Roles, resp.Permissions)
+		return nil
+
+	case "webhooks":
+		resp, err := client.ListWebhooks(ctx, &pb.ListWebhooksRequest{})
+		if err != nil {
+			return err
+		}
+		for _, w := range resp.Webhooks {
+			fmt.Printf("%s %s %v\n", w.Id, w.Url, w.Events)
+		}
+		return nil
+
+	case "webhook-add":
+		if err := need(2); err != nil {
+			return err
+		}
+		resp, err := client.RegisterWebhook(ctx, &pb.RegisterWebhookRequest{Url: args[0], Secret: args[1], Events: args[2:]})
+		return report(resp, err)
+
+	case "webhook-delete":
+		if err := need(1); err != nil {
+			return err
+		}
+		resp, err := client.DeleteWebhook(ctx, &pb.DeleteWebhookRequest{Id: args[0]})
+		return report(resp, err)
+
+	case "dead-letters":
+		resp, err := client.ListDeadLetters(ctx, &pb.ListDeadLettersRequest{})
+		if err != nil {
+			return err
+		}
+		for _, dl := range resp.DeadLetters {
+			fmt.Printf("%s webhook=%s event=%s (%s) attempts=%d: %s\n",
+				dl.Id, dl.WebhookId, dl.EventId, dl.EventType, dl.Attempts, dl.LastError)
+		}
+		return nil
+
+	case "replay":
+		if err := need(1); err != nil {
+			return err
+		}
+		req := &pb.ReplayWebhookRequest{DeadLetterId: args[0]}
+		if args[0] == "all" {
+			req = &pb.ReplayWebhookRequest{All: true}
+		}
+		resp, err := client.ReplayWebhook(ctx, req)
+		return report(resp, err)
+	}
+
+	return fmt.Errorf("unknown command %q, see -h", command)
+}
+
+// report prints the outcome of a mutating admin call
+func report(resp interface {
+	GetSuccess() bool
+	GetMessage() string
+}, err error) error {
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s (Success: %v)\n", resp.GetMessage(), resp.GetSuccess())
+	return nil
+}
@@ -2,25 +2,87 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	pb "github.com/930r91na/Subasta-grpc/pkg/auction" // Update this import path
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/930r91na/Subasta-grpc/pkg/config"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
+// Config holds the demo client's settings
+type Config struct {
+	Network networkConfig `yaml:"network"`
+	TLS     tlsConfig     `yaml:"tls"`
+}
+
+// networkConfig is the server the client calls
+type networkConfig struct {
+	Server  string        `yaml:"server" flag:"server" usage:"address of the auction server's gRPC API"`
+	Timeout time.Duration `yaml:"timeout" flag:"timeout" usage:"how long the whole demo may take"`
+}
+
+// tlsConfig secures the connection to the server
+type tlsConfig struct {
+	CA   string `yaml:"ca" flag:"tls-ca" usage:"PEM CA certificates to verify the server against (plaintext when empty)"`
+	Cert string `yaml:"cert" flag:"tls-cert" usage:"PEM client certificate, which identifies the user it was issued to"`
+	Key  string `yaml:"key" flag:"tls-key" usage:"PEM private key of the client certificate"`
+}
+
+// Validate reports every setting that is out of range or unreadable
+func (c *Config) Validate() error {
+	var errs config.Errors
+	if err := config.CheckAddr(c.Network.Server); err != nil {
+		errs.Addf("network.server", "%v", err)
+	}
+	if c.Network.Timeout <= 0 {
+		errs.Addf("network.timeout", "must be positive")
+	}
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		errs.Addf("tls.key", "cert and key must be set together")
+	}
+	if c.TLS.CA == "" && c.TLS.Cert != "" {
+		errs.Addf("tls.ca", "must be set to present a certificate")
+	}
+	return errs.Err()
+}
+
 func main() {
+	cfg := &Config{}
+	cfg.Network.Server = "localhost:50051"
+	cfg.Network.Timeout = 10 * time.Second
+	printConfig, err := config.Load(cfg, "client", "AUCTION_CLIENT_", os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if printConfig {
+		config.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if printConfig {
+		return
+	}
+
 	// Connect to the server
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := certs.ClientCredentials(cfg.TLS.CA, cfg.TLS.Cert, cfg.TLS.Key)
+	if err != nil {
+		log.Fatalf("Failed to load certificates: %v", err)
+	}
+	conn, err := grpc.Dial(cfg.Network.Server, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatalf("Could not connect: %v", err)
 	}
 	defer conn.Close()
 
 	client := pb.NewAuctionServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Network.Timeout)
 	defer cancel()
 
 	// Initialize the gRPC client with mock interactions
@@ -133,4 +195,44 @@ func main() {
 	} else {
 		fmt.Println("Product not found")
 	}
+
+	// Example 7: Close an auction
+	fmt.Println("\n=== Closing Auction ===")
+	closeResp, err := client.CloseAuction(ctx, &pb.CloseAuctionRequest{
+		Seller:  "John",
+		Product: "Laptop",
+	})
+	if err != nil {
+		log.Fatalf("Error closing auction: %v", err)
+	}
+	fmt.Printf("%s (Success: %v)\n", closeResp.Message, closeResp.Success)
+
+	// Example 8: Ledger statements after the sale; the house's accounts are
+	// for staff only
+	fmt.Println("\n=== Statements ===")
+	for _, account := range []string{"Peter", "John"} {
+		stmt, err := client.GetStatement(ctx, &pb.GetStatementRequest{Account: account})
+		if err != nil {
+			log.Printf("Error getting statement: %v", err)
+			continue
+		}
+		fmt.Printf("%s (Balance: $%.2f)\n", stmt.Account, float64(stmt.BalanceCents)/100)
+		for _, line := range stmt.Lines {
+			fmt.Printf("  #%d %-10s %-35s $%.2f\n", line.EntryId, line.Kind, line.Memo, float64(line.AmountCents)/100)
+		}
+	}
+
+	// Example 9: Notifications generated by the bids and the sale
+	fmt.Println("\n=== Notifications ===")
+	for _, name := range users {
+		inbox, err := client.ListNotifications(ctx, &pb.ListNotificationsRequest{User: name})
+		if err != nil {
+			log.Printf("Error listing notifications: %v", err)
+			continue
+		}
+		fmt.Printf("%s (%d unread)\n", name, inbox.Unread)
+		for _, n := range inbox.Notifications {
+			fmt.Printf("  [%s] %s\n", n.Kind, n.Message)
+		}
+	}
 }
@@ -0,0 +1,57 @@
+// Command devca issues TLS certificates from a local CA for development and
+// tests. The CA is created in the directory on first use; every name given
+// gets a certificate, name.pem and name-key.pem, that serves the -hosts and
+// identifies name as a client:
+//
+//	go run ./cmd/devca -dir certs server webserver
+//	go run ./cmd/devca -dir certs n1 n2 n3 John Mary
+//
+// Certificates identify users by their common name, so a certificate issued
+// as John may act as the user John.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+)
+
+func main() {
+	dir := flag.String("dir", "certs", "directory of the CA and the certificates it issues")
+	hosts := flag.String("hosts", "localhost,127.0.0.1,::1", "comma separated DNS names and IP addresses the certificates serve")
+	validity := flag.Duration("validity", 90*24*time.Hour, "how long issued certificates are valid")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: devca [flags] <name>...\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	ca, err := certs.LoadCA(*dir)
+	if os.IsNotExist(err) {
+		if ca, err = certs.NewCA("Auction development CA", 10*365*24*time.Hour); err == nil {
+			err = ca.Save(*dir)
+		}
+		if err == nil {
+			fmt.Printf("Created CA %s\n", filepath.Join(*dir, "ca.pem"))
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to load CA: %v", err)
+	}
+
+	for _, name := range flag.Args() {
+		if name == "ca" || name == "ca-key" || strings.ContainsAny(name, `/\`) {
+			log.Fatalf("Invalid certificate name %q", name)
+		}
+		if err := ca.IssueFiles(*dir, name, strings.Split(*hosts, ","), *validity); err != nil {
+			log.Fatalf("Failed to issue %s: %v", name, err)
+		}
+		fmt.Printf("Issued %s\n", filepath.Join(*dir, name+".pem"))
+	}
+}
@@ -0,0 +1,260 @@
+// Command loadgen measures bidding throughput of a running auction server
+// as the number of products being bid on grows. Start the server without
+// rate limits first:
+//
+//	go run ./cmd/server -rate-limits "*=off"
+//	go run ./cmd/loadgen -products 1,4,16,64 -workers 64
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/930r91na/Subasta-grpc/pkg/config"
+	"google.golang.org/grpc"
+)
+
+// Config holds the load generator's settings; see package config for how
+// they are loaded
+type Config struct {
+	Network networkConfig `yaml:"network"`
+	TLS     tlsConfig     `yaml:"tls"`
+	Load    loadConfig    `yaml:"load"`
+}
+
+// networkConfig is the server put under load
+type networkConfig struct {
+	Server string `yaml:"server" flag:"server" usage:"address of the auction server's gRPC API"`
+}
+
+// tlsConfig secures the connection to the server
+type tlsConfig struct {
+	CA   string `yaml:"ca" flag:"tls-ca" usage:"PEM CA certificates to verify the server against (plaintext when empty)"`
+	Cert string `yaml:"cert" flag:"tls-cert" usage:"PEM client certificate, which must be trusted as a proxy to bid for many users"`
+	Key  string `yaml:"key" flag:"tls-key" usage:"PEM private key of the client certificate"`
+}
+
+// loadConfig shapes the runs
+type loadConfig struct {
+	Products string        `yaml:"products" flag:"products" usage:"comma separated numbers of products to bid on, one run each"`
+	Workers  int           `yaml:"workers" flag:"workers" usage:"concurrent bidders, spread evenly over the products"`
+	Duration time.Duration `yaml:"duration" flag:"duration" usage:"length of each run"`
+}
+
+// Validate reports every setting that is out of range or unreadable
+func (c *Config) Validate() error {
+	var errs config.Errors
+	if err := config.CheckAddr(c.Network.Server); err != nil {
+		errs.Addf("network.server", "%v", err)
+	}
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		errs.Addf("tls.key", "cert and key must be set together")
+	}
+	if c.TLS.CA == "" && c.TLS.Cert != "" {
+		errs.Addf("tls.ca", "must be set to present a certificate")
+	}
+	if _, err := parseCounts(c.Load.Products); err != nil {
+		errs.Addf("load.products", "%v", err)
+	}
+	if c.Load.Workers < 1 {
+		errs.Addf("load.workers", "must be at least 1")
+	}
+	if c.Load.Duration <= 0 {
+		errs.Addf("load.duration", "must be positive")
+	}
+	return errs.Err()
+}
+
+// parseCounts parses comma separated product counts
+func parseCounts(s string) ([]int, error) {
+	var counts []int
+	for _, c := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(c))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid product count %q", c)
+		}
+		counts = append(counts, n)
+	}
+	return counts, nil
+}
+
+// result is what one run measured
+type result struct {
+	products  int
+	bids      int64
+	accepted  int64
+	failed    int64
+	elapsed   time.Duration
+	latencies []time.Duration
+}
+
+func main() {
+	cfg := &Config{}
+	cfg.Network.Server = "localhost:50051"
+	cfg.Load.Products = "1,4,16,64"
+	cfg.Load.Workers = 64
+	cfg.Load.Duration = 5 * time.Second
+	printConfig, err := config.Load(cfg, "loadgen", "AUCTION_LOADGEN_", os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if printConfig {
+		config.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if printConfig {
+		return
+	}
+	counts, _ := parseCounts(cfg.Load.Products)
+
+	creds, err := certs.ClientCredentials(cfg.TLS.CA, cfg.TLS.Cert, cfg.TLS.Key)
+	if err != nil {
+		log.Fatalf("Failed to load certificates: %v", err)
+	}
+	conn, err := grpc.NewClient(cfg.Network.Server, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		log.Fatalf("Could not connect: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewAuctionServiceClient(conn)
+
+	// Names are unique per invocation so runs can be repeated against the
+	// same server
+	prefix := fmt.Sprintf("loadgen-%d", time.Now().Unix())
+	users := make([]string, cfg.Load.Workers)
+	for i := range users {
+		users[i] = fmt.Sprintf("%s-bidder-%d", prefix, i)
+	}
+	seller := prefix + "-seller"
+	if err := register(client, append(users, seller)); err != nil {
+		log.Fatalf("Failed to register users: %v", err)
+	}
+
+	fmt.Printf("%-9s %10s %10s %9s %9s %9s\n", "products", "bids/s", "accepted", "failed", "p50", "p99")
+	for run, n := range counts {
+		products := make([]string, n)
+		for i := range products {
+			products[i] = fmt.Sprintf("%s-run%d-item%d", prefix, run, i)
+		}
+		if err := list(client, seller, products); err != nil {
+			log.Fatalf("Failed to list products: %v", err)
+		}
+
+		r := bid(client, users, products, cfg.Load.Duration)
+		sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+		fmt.Printf("%-9d %10.0f %10d %9d %9s %9s\n", r.products,
+			float64(r.bids)/r.elapsed.Seconds(), r.accepted, r.failed,
+			percentile(r.latencies, 0.50), percentile(r.latencies, 0.99))
+	}
+}
+
+// register creates the users taking part in the runs
+func register(client pb.AuctionServiceClient, users []string) error {
+	for _, name := range users {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := client.RegisterUser(ctx, &pb.RegisterUserRequest{Name: name})
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list puts the products of a run up for auction
+func list(client pb.AuctionServiceClient, seller string, products []string) error {
+	for _, product := range products {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := client.AddProduct(ctx, &pb.AddProductRequest{
+			Seller:       seller,
+			Product:      product,
+			InitialPrice: 1,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return fmt.Errorf("%s: %s", product, resp.Message)
+		}
+	}
+	return nil
+}
+
+// bid has every user outbid the others on their product until the run is
+// over. User i bids on product i mod len(products).
+func bid(client pb.AuctionServiceClient, users, products []string, d time.Duration) result {
+	var bids, accepted, failed atomic.Int64
+	latencies := make([][]time.Duration, len(users))
+
+	deadline := time.Now().Add(d)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i, user := range users {
+		wg.Add(1)
+		go func(i int, user string) {
+			defer wg.Done()
+			product := products[i%len(products)]
+			price := float32(1)
+
+			for time.Now().Before(deadline) {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				t := time.Now()
+				resp, err := client.PlaceBid(ctx, &pb.PlaceBidRequest{
+					Buyer:   user,
+					Product: product,
+					Amount:  price + 1,
+				})
+				latencies[i] = append(latencies[i], time.Since(t))
+				cancel()
+
+				bids.Add(1)
+				if err != nil {
+					failed.Add(1)
+					continue
+				}
+				if resp.Success {
+					accepted.Add(1)
+				}
+				price = resp.CurrentPrice
+			}
+		}(i, user)
+	}
+	wg.Wait()
+
+	r := result{
+		products: len(products),
+		bids:     bids.Load(),
+		accepted: accepted.Load(),
+		failed:   failed.Load(),
+		elapsed:  time.Since(start),
+	}
+	for _, l := range latencies {
+		r.latencies = append(r.latencies, l...)
+	}
+	return r
+}
+
+// percentile returns the p-th percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	d := sorted[int(float64(len(sorted)-1)*p)]
+	return d.Round(10 * time.Microsecond)
+}
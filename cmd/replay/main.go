@@ -0,0 +1,157 @@
+// Command replay rebuilds the auction's state from the server's event log
+// as it was at any point in its history:
+//
+//	go run ./cmd/replay -log data/events.log
+//	go run ./cmd/replay -until 2026-10-18T12:00:00Z
+//	go run ./cmd/replay -seq 120 -events
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+)
+
+func main() {
+	logPath := flag.String("log", "data/events.log", "event log written by the auction server")
+	untilFlag := flag.String("until", "", "replay the events recorded up to this RFC 3339 time")
+	seq := flag.Uint64("seq", 0, "replay the events up to this sequence number (0 for all)")
+	showEvents := flag.Bool("events", false, "print the replayed events before the state")
+	asJSON := flag.Bool("json", false, "print the state as JSON")
+	flag.Parse()
+
+	var until time.Time
+	if *untilFlag != "" {
+		t, err := time.Parse(time.RFC3339, *untilFlag)
+		if err != nil {
+			log.Fatalf("Invalid -until time: %v", err)
+		}
+		until = t
+	}
+
+	recorded, err := events.ReadFile(*logPath)
+	if err != nil {
+		log.Fatalf("Failed to read event log: %v", err)
+	}
+
+	st := events.NewState()
+	var last time.Time
+	for _, e := range recorded {
+		if *seq > 0 && e.Seq > *seq || !until.IsZero() && e.Time.After(until) {
+			break
+		}
+		if *showEvents {
+			printEvent(e)
+		}
+		st.Apply(e)
+		last = e.Time
+	}
+
+	if *asJSON {
+		printJSON(st)
+		return
+	}
+	if *showEvents {
+		fmt.Println()
+	}
+	printState(st, last)
+}
+
+// printEvent prints one event on a line
+func printEvent(e events.Event) {
+	data, _ := json.Marshal(e.Data)
+	fmt.Printf("%6d  %s  %-20s %s\n", e.Seq, e.Time.Format(time.RFC3339), e.Type(), data)
+}
+
+// printState prints the users, products and bids of a replayed state
+func printState(st *events.State, last time.Time) {
+	if st.Seq == 0 {
+		fmt.Println("No events replayed")
+		return
+	}
+	fmt.Printf("State after event %d (%s)\n", st.Seq, last.Format(time.RFC3339))
+
+	fmt.Printf("\nUsers (%d):\n", len(st.Users))
+	for _, u := range sortedUsers(st) {
+		flags := ""
+		if u.Suspended {
+			flags = " [suspended]"
+		}
+		fmt.Printf("  %s%s\n", u.Name, flags)
+	}
+
+	fmt.Printf("\nProducts (%d):\n", len(st.Products))
+	for _, p := range sortedProducts(st) {
+		info := p.Info
+		state := "open"
+		switch {
+		case info.Closed:
+			state = "closed"
+		case info.Frozen:
+			state = "frozen"
+		}
+		fmt.Printf("  %s by %s: %.2f (started at %.2f), %s, version %d\n",
+			info.Product, info.Seller, info.CurrentPrice, info.InitialPrice, state, info.Version)
+		if info.HighestBidder != "" {
+			fmt.Printf("    highest bidder: %s\n", info.HighestBidder)
+		}
+		for _, b := range sortedBids(p) {
+			fmt.Printf("    bid %.2f by %s\n", b.Amount, b.Buyer)
+		}
+	}
+}
+
+// printJSON prints a replayed state in the shape of GetServerState
+func printJSON(st *events.State) {
+	out := struct {
+		Seq      uint64            `json:"seq"`
+		Users    []*pb.User        `json:"users"`
+		Products []*pb.ProductInfo `json:"products"`
+		Bids     []*pb.BidInfo     `json:"bids"`
+	}{Seq: st.Seq, Users: sortedUsers(st)}
+	for _, p := range sortedProducts(st) {
+		out.Products = append(out.Products, p.Info)
+		out.Bids = append(out.Bids, sortedBids(p)...)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		log.Fatalf("Failed to encode state: %v", err)
+	}
+}
+
+func sortedUsers(st *events.State) []*pb.User {
+	users := make([]*pb.User, 0, len(st.Users))
+	for _, u := range st.Users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users
+}
+
+func sortedProducts(st *events.State) []*events.Product {
+	products := make([]*events.Product, 0, len(st.Products))
+	for _, p := range st.Products {
+		products = append(products, p)
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Info.Product < products[j].Info.Product })
+	return products
+}
+
+// sortedBids returns a product's bids, highest first
+func sortedBids(p *events.Product) []*pb.BidInfo {
+	bids := make([]*pb.BidInfo, 0, len(p.Bids))
+	for _, b := range p.Bids {
+		bids = append(bids, b)
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Amount > bids[j].Amount })
+	return bids
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/audit"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Event types for moderation actions delivered to webhooks
+const (
+	eventUserSuspended  = "user.suspended"
+	eventProductRemoved = "product.removed"
+	eventProductFrozen  = "product.frozen"
+	eventBidVoided      = "bid.voided"
+)
+
+// AdminServer implements the privileged AuctionAdminService on top of the
+// state owned by an AuctionServer
+type AdminServer struct {
+	pb.UnimplementedAuctionAdminServiceServer
+	auction *AuctionServer
+	tokens  staffTokens
+}
+
+// NewAdminServer creates the admin service for an auction server. Only
+// holders of one of the tokens may call it.
+func NewAdminServer(auction *AuctionServer, tokens staffTokens) *AdminServer {
+	return &AdminServer{
+		auction: auction,
+		tokens:  tokens,
+	}
+}
+
+// auditProjection lists the moderation actions and role changes recorded
+// in the event log, so every node shows the same audit log
+type auditProjection struct {
+	log *audit.Log
+}
+
+// Apply implements events.Projection
+func (p auditProjection) Apply(e events.Event) {
+	entry := audit.Entry{Time: e.Time, Success: true}
+	switch d := e.Data.(type) {
+	case *events.UserSuspended:
+		state := "reinstated"
+		if d.Suspended {
+			state = "suspended"
+		}
+		entry.Actor, entry.Action, entry.Reason = d.Actor, "SuspendUser", d.Reason
+		entry.Message = fmt.Sprintf("User %s %s", d.Name, state)
+	case *events.ProductRemoved:
+		entry.Actor, entry.Action, entry.Reason = d.Actor, "RemoveProduct", d.Reason
+		entry.Message = fmt.Sprintf("Product %s removed", d.Product)
+	case *events.ProductFrozen:
+		state := "unfrozen"
+		if d.Frozen {
+			state = "frozen"
+		}
+		entry.Actor, entry.Action, entry.Reason = d.Actor, "FreezeProduct", d.Reason
+		entry.Message = fmt.Sprintf("Auction for %s %s", d.Product, state)
+	case *events.BidVoided:
+		entry.Actor, entry.Action, entry.Reason = d.Actor, "VoidBid", d.Reason
+		entry.Message = fmt.Sprintf("Bid by %s on %s voided", d.Buyer, d.Product)
+	case *events.AuctionClosed:
+		if d.Actor == "" {
+			// Closed by its seller
+			return
+		}
+		entry.Actor, entry.Action, entry.Reason = d.Actor, "ForceCloseAuction", d.Reason
+		entry.Message = fmt.Sprintf("Auction for %s closed", d.Product)
+	case *events.RoleGranted:
+		entry.Actor, entry.Action, entry.Reason = d.Actor, "GrantRole", d.Reason
+		entry.Message = fmt.Sprintf("%s granted the %s role", d.User, d.Role)
+	case *events.RoleRevoked:
+		entry.Actor, entry.Action, entry.Reason = d.Actor, "RevokeRole", d.Reason
+		entry.Message = fmt.Sprintf("%s no longer has the %s role", d.User, d.Role)
+	default:
+		return
+	}
+
+	entry.Action = adminServicePrefix + entry.Action
+	if data, err := json.Marshal(e.Data); err == nil {
+		entry.Request = string(data)
+	}
+	p.log.Record(entry)
+}
+
+// SuspendUser suspends or reinstates a user
+func (a *AdminServer) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.SuspendUserResponse, error) {
+	s := a.auction
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+
+	name := req.GetUser()
+	user, exists := s.users[name]
+	if !exists {
+		return &pb.SuspendUserResponse{
+			Success: false,
+			Message: fmt.Sprintf("User %s does not exist", name),
+		}, nil
+	}
+
+	e, err := s.record(ctx, &events.UserSuspended{Name: name, Suspended: req.GetSuspended(), Reason: req.GetReason(), Actor: adminFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	events.ApplyUser(s.users, e)
+	s.publish(eventUserSuspended, map[string]interface{}{
+		"name":      name,
+		"suspended": user.Suspended,
+		"reason":    req.GetReason(),
+	})
+
+	state := "reinstated"
+	if user.Suspended {
+		state = "suspended"
+	}
+	slog.InfoContext(ctx, "User "+state, "user", name, "admin", adminFromContext(ctx), "reason", req.GetReason())
+	return &pb.SuspendUserResponse{
+		Success: true,
+		Message: fmt.Sprintf("User %s %s", name, state),
+	}, nil
+}
+
+// RemoveProduct deletes a listing with its bids and watchers, refunding the
+// sale if it had already been sold
+func (a *AdminServer) RemoveProduct(ctx context.Context, req *pb.RemoveProductRequest) (*pb.RemoveProductResponse, error) {
+	s := a.auction
+
+	product := req.GetProduct()
+	listing := s.lockAuction(product)
+	if listing == nil {
+		return &pb.RemoveProductResponse{
+			Success: false,
+			Message: fmt.Sprintf("Product %s does not exist", product),
+		}, nil
+	}
+	productInfo := listing.Info
+
+	// Only this listing is locked while the event is recorded. The ledger
+	// refunds a sold listing and everyone involved is notified as
+	// projections of it.
+	if _, err := s.record(ctx, &events.ProductRemoved{Product: product, Reason: req.GetReason(), Actor: adminFromContext(ctx)}); err != nil {
+		listing.mu.Unlock()
+		return nil, err
+	}
+	listing.removed = true
+	listing.snapshot.Store(nil)
+	listing.mu.Unlock()
+
+	// Removed, the listing is no longer found, so it can leave the catalog
+	// without holding both locks out of order
+	s.mu.Lock()
+	if s.products[product] == listing {
+		delete(s.products, product)
+	}
+	s.mu.Unlock()
+	forgetProductMetrics(product)
+	s.publish(eventProductRemoved, map[string]interface{}{
+		"product": product,
+		"seller":  productInfo.Seller,
+		"reason":  req.GetReason(),
+	})
+
+	slog.InfoContext(ctx, "Product removed", "product", product, "admin", adminFromContext(ctx), "reason", req.GetReason())
+	return &pb.RemoveProductResponse{
+		Success: true,
+		Message: fmt.Sprintf("Product %s removed", product),
+	}, nil
+}
+
+// FreezeProduct stops or resumes bidding on a listing
+func (a *AdminServer) FreezeProduct(ctx context.Context, req *pb.FreezeProductRequest) (*pb.FreezeProductResponse, error) {
+	s := a.auction
+
+	product := req.GetProduct()
+	listing := s.lockAuction(product)
+	if listing == nil {
+		return &pb.FreezeProductResponse{
+			Success: false,
+			Message: fmt.Sprintf("Product %s does not exist", product),
+		}, nil
+	}
+	defer listing.mu.Unlock()
+	productInfo := listing.Info
+
+	if productInfo.Closed {
+		return &pb.FreezeProductResponse{
+			Success: false,
+			Message: fmt.Sprintf("Auction for %s is already closed", product),
+		}, nil
+	}
+
+	e, err := s.record(ctx, &events.ProductFrozen{Product: product, Frozen: req.GetFrozen(), Reason: req.GetReason(), Actor: adminFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	listing.apply(e)
+	s.publish(eventProductFrozen, map[string]interface{}{
+		"product": product,
+		"frozen":  productInfo.Frozen,
+		"reason":  req.GetReason(),
+	})
+
+	state := "unfrozen"
+	if productInfo.Frozen {
+		state = "frozen"
+	}
+	slog.InfoContext(ctx, "Product "+state, "product", product, "admin", adminFromContext(ctx), "reason", req.GetReason())
+	return &pb.FreezeProductResponse{
+		Success: true,
+		Message: fmt.Sprintf("Auction for %s %s", product, state),
+	}, nil
+}
+
+// VoidBid removes a buyer's bid from an open auction. If it was the highest
+// bid, the next highest remaining bid, or the initial price, takes its place.
+func (a *AdminServer) VoidBid(ctx context.Context, req *pb.VoidBidRequest) (*pb.VoidBidResponse, error) {
+	s := a.auction
+
+	product := req.GetProduct()
+	buyer := req.GetBuyer()
+
+	listing := s.lockAuction(product)
+	if listing == nil {
+		return &pb.VoidBidResponse{
+			Success: false,
+			Message: fmt.Sprintf("Product %s does not exist", product),
+		}, nil
+	}
+	defer listing.mu.Unlock()
+	productInfo := listing.Info
+
+	if productInfo.Closed {
+		return &pb.VoidBidResponse{
+			Success:       false,
+			Message:       fmt.Sprintf("Auction for %s is already closed", product),
+			CurrentPrice:  productInfo.CurrentPrice,
+			HighestBidder: productInfo.HighestBidder,
+		}, nil
+	}
+
+	if _, exists := listing.Bids[buyer]; !exists {
+		return &pb.VoidBidResponse{
+			Success:       false,
+			Message:       fmt.Sprintf("%s has no bid on %s", buyer, product),
+			CurrentPrice:  productInfo.CurrentPrice,
+			HighestBidder: productInfo.HighestBidder,
+		}, nil
+	}
+
+	// The next highest remaining bid, or the initial price, takes its place
+	e, err := s.record(ctx, &events.BidVoided{Buyer: buyer, Product: product, Reason: req.GetReason(), Actor: adminFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	listing.apply(e)
+
+	s.publish(eventBidVoided, map[string]interface{}{
+		"product":        product,
+		"buyer":          buyer,
+		"current_price":  productInfo.CurrentPrice,
+		"highest_bidder": productInfo.HighestBidder,
+		"reason":         req.GetReason(),
+	})
+
+	slog.InfoContext(ctx, "Bid voided", "buyer", buyer, "product", product, "admin", adminFromContext(ctx), "reason", req.GetReason())
+	return &pb.VoidBidResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Bid by %s on %s voided", buyer, product),
+		CurrentPrice:  productInfo.CurrentPrice,
+		HighestBidder: productInfo.HighestBidder,
+	}, nil
+}
+
+// ForceCloseAuction closes an auction on the seller's behalf, even if frozen
+func (a *AdminServer) ForceCloseAuction(ctx context.Context, req *pb.ForceCloseAuctionRequest) (*pb.ForceCloseAuctionResponse, error) {
+	s := a.auction
+
+	product := req.GetProduct()
+	listing := s.lockAuction(product)
+	if listing == nil {
+		return &pb.ForceCloseAuctionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Product %s does not exist", product),
+		}, nil
+	}
+	defer listing.mu.Unlock()
+	productInfo := listing.Info
+
+	if productInfo.Closed {
+		return &pb.ForceCloseAuctionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Auction for %s is already closed", product),
+		}, nil
+	}
+
+	// Closing unfreezes the auction
+	resp, err := s.closeAuction(ctx, listing, adminFromContext(ctx), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Auction force-closed", "product", product, "admin", adminFromContext(ctx), "reason", req.GetReason())
+	return &pb.ForceCloseAuctionResponse{
+		Success:    resp.Success,
+		Message:    resp.Message,
+		Winner:     resp.Winner,
+		FinalPrice: resp.FinalPrice,
+	}, nil
+}
+
+// GetServerState returns a copy of the users, products and bids held in memory
+func (a *AdminServer) GetServerState(ctx context.Context, req *pb.GetServerStateRequest) (*pb.GetServerStateResponse, error) {
+	s := a.auction
+
+	resp := &pb.GetServerStateResponse{
+		Webhooks:    int32(len(s.webhooks.Subscriptions())),
+		DeadLetters: int32(len(s.webhooks.DeadLetters())),
+	}
+	for _, listing := range s.auctions() {
+		listing.mu.Lock()
+		if !listing.removed {
+			resp.Products = append(resp.Products, listing.snapshot.Load())
+			for _, b := range listing.Bids {
+				resp.Bids = append(resp.Bids, proto.Clone(b).(*pb.BidInfo))
+			}
+		}
+		listing.mu.Unlock()
+	}
+
+	s.usersMu.RLock()
+	for _, u := range s.users {
+		resp.Users = append(resp.Users, s.userView(u))
+	}
+	s.usersMu.RUnlock()
+
+	sort.Slice(resp.Users, func(i, j int) bool { return resp.Users[i].Name < resp.Users[j].Name })
+	sort.Slice(resp.Products, func(i, j int) bool { return resp.Products[i].Product < resp.Products[j].Product })
+	sort.Slice(resp.Bids, func(i, j int) bool {
+		if resp.Bids[i].Product != resp.Bids[j].Product {
+			return resp.Bids[i].Product < resp.Bids[j].Product
+		}
+		return resp.Bids[i].Amount > resp.Bids[j].Amount
+	})
+	return resp, nil
+}
+
+// ListAuditLog returns the most recent moderation actions and role changes
+func (a *AdminServer) ListAuditLog(ctx context.Context, req *pb.ListAuditLogRequest) (*pb.ListAuditLogResponse, error) {
+	list := a.auction.audit.List(int(req.GetLimit()))
+
+	entries := make([]*pb.AuditEntry, 0, len(list))
+	for _, e := range list {
+		entries = append(entries, &pb.AuditEntry{
+			Id:      e.ID,
+			Time:    timestamppb.New(e.Time),
+			Actor:   e.Actor,
+			Action:  e.Action,
+			Request: e.Request,
+			Success: e.Success,
+			Message: e.Message,
+			Reason:  e.Reason,
+		})
+	}
+	return &pb.ListAuditLogResponse{Entries: entries}, nil
+}
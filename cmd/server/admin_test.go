@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"github.com/930r91na/Subasta-grpc/pkg/webhook"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAuditLog(t *testing.T) {
+	s := newTestServer(t)
+	products := listProducts(t, s, 3)
+	admin := NewAdminServer(s, nil)
+	ctx := context.WithValue(context.Background(), adminContextKey{}, "mod")
+
+	if resp, err := admin.SuspendUser(ctx, &pb.SuspendUserRequest{User: "seller", Suspended: true, Reason: "shill bidding"}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("SuspendUser = %v, %v", resp, err)
+	}
+	if resp, err := admin.RemoveProduct(ctx, &pb.RemoveProductRequest{Product: products[0], Reason: "counterfeit"}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("RemoveProduct = %v, %v", resp, err)
+	}
+	if resp, err := admin.ForceCloseAuction(ctx, &pb.ForceCloseAuctionRequest{Product: products[1], Reason: "seller gone"}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("ForceCloseAuction = %v, %v", resp, err)
+	}
+	// Neither a failed action nor a seller closing their own auction is audited
+	if resp, _ := admin.RemoveProduct(ctx, &pb.RemoveProductRequest{Product: "missing"}); resp.GetSuccess() {
+		t.Fatal("missing product removed")
+	}
+	if resp, err := s.CloseAuction(ctx, &pb.CloseAuctionRequest{Seller: "seller", Product: products[2]}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("CloseAuction = %v, %v", resp, err)
+	}
+
+	resp, err := admin.ListAuditLog(ctx, &pb.ListAuditLogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ action, reason string }{
+		{"ForceCloseAuction", "seller gone"},
+		{"RemoveProduct", "counterfeit"},
+		{"SuspendUser", "shill bidding"},
+	}
+	if len(resp.GetEntries()) != len(want) {
+		t.Fatalf("audit log = %v, want %d entries", resp.GetEntries(), len(want))
+	}
+	for i, e := range resp.GetEntries() {
+		if e.GetActor() != "mod" || e.GetAction() != adminServicePrefix+want[i].action || e.GetReason() != want[i].reason {
+			t.Errorf("entry %d = %v, want %s by mod for %q", i, e, want[i].action, want[i].reason)
+		}
+	}
+
+	// Another node, or this one restarted, lists the same entries
+	webhooks, _ := webhook.NewDispatcher(webhook.Options{})
+	defer webhooks.Stop()
+	replica := NewAuctionServer(s.events, ledger.DefaultSchedule, notify.NewInbox(), webhooks, nil, rbac.NewStore(rbac.DefaultPolicy))
+	got, err := NewAdminServer(replica, nil).ListAuditLog(ctx, &pb.ListAuditLogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, resp) {
+		t.Errorf("rebuilt audit log:\n%v\nwant\n%v", got, resp)
+	}
+}
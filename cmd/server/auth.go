@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminServicePrefix prefixes the full method names of the admin service
+const adminServicePrefix = "/auction.AuctionAdminService/"
+
+// staffTokens maps bearer tokens to the names of the staff holding them.
+// What each of them may do is decided by their roles.
+type staffTokens map[string]string
+
+// parseStaffTokens parses a comma separated list of "name=token" pairs into
+// tokens, rejecting tokens that are already taken
+func parseStaffTokens(tokens staffTokens, s string) ([]string, error) {
+	var names []string
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, token, ok := strings.Cut(pair, "=")
+		if !ok || name == "" || token == "" {
+			return nil, fmt.Errorf("token %q: want name=token", pair)
+		}
+		if _, dup := tokens[token]; dup {
+			return nil, fmt.Errorf("token for %s is not unique", name)
+		}
+		tokens[token] = name
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// authenticate returns the staff member named by the request's bearer token
+func (t staffTokens) authenticate(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	name, ok := t[token]
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	return name, nil
+}
+
+type adminContextKey struct{}
+
+// adminFromContext returns the authenticated staff member of an admin request
+func adminFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(adminContextKey{}).(string)
+	return name
+}
+
+// UnaryInterceptor authenticates calls to the admin service. Other services
+// pass through untouched.
+func (a *AdminServer) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, adminServicePrefix) {
+		return handler(ctx, req)
+	}
+
+	admin, err := a.tokens.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, adminContextKey{}, admin), req)
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"path"
+	"strings"
+	"sync"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/cluster"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// followerReads are the calls a follower answers from its own replica of
+// the users, catalog and notifications. Everything else, including the
+// webhooks the leader keeps, is handled by the leader.
+var followerReads = map[string]bool{
+	"GetCatalog":             true,
+	"GetProduct":             true,
+	"GetProfile":             true,
+	"ListWatchlist":          true,
+	"GetStatement":           true,
+	"ListNotifications":      true,
+	"SubscribeAuctionEvents": true,
+	"SubscribeNotifications": true,
+}
+
+// ownService reports whether a call is to one of the auction's services.
+// Health checks and reflection are answered by every node itself.
+func ownService(fullMethod string) bool {
+	service := strings.TrimPrefix(path.Dir(fullMethod), "/")
+	return service == pb.AuctionService_ServiceDesc.ServiceName ||
+		service == pb.AuctionAdminService_ServiceDesc.ServiceName
+}
+
+// joinCluster opens this server's member of a cluster and replicates the
+// event log through it. Every node raises the same notifications, but only
+// the leader emails them.
+func (s *AuctionServer) joinCluster(cfg cluster.Config) (*cluster.Node, error) {
+	s.clustered = true
+	node, err := cluster.Open(cfg, s.events, s.applyReplicated)
+	if err != nil {
+		return nil, err
+	}
+	s.node.Store(node)
+	s.events.ReplicateTo(node)
+	return node, nil
+}
+
+// mailing reports whether this server emails the notifications it raises
+func (s *AuctionServer) mailing() bool {
+	if !s.clustered {
+		return true
+	}
+	node := s.node.Load()
+	return node != nil && node.IsLeader()
+}
+
+// applyReplicated folds an event committed by another node into the live
+// state. Webhooks were sent by the node that appended it; notifications are
+// raised on every node by their projection.
+func (s *AuctionServer) applyReplicated(e events.Event) {
+	switch d := e.Data.(type) {
+	case *events.UserRegistered, *events.ProfileUpdated, *events.UserSuspended:
+		s.usersMu.Lock()
+		events.ApplyUser(s.users, e)
+		s.usersMu.Unlock()
+		s.applyRoles(e)
+
+	case *events.RoleGranted, *events.RoleRevoked:
+		s.applyRoles(e)
+
+	case *events.ProductListed:
+		s.mu.Lock()
+		s.products[d.Product] = newAuction(d)
+		s.mu.Unlock()
+
+	case *events.ProductRemoved:
+		s.mu.Lock()
+		if a, exists := s.products[d.Product]; exists {
+			a.mu.Lock()
+			a.removed = true
+			a.snapshot.Store(nil)
+			a.mu.Unlock()
+			delete(s.products, d.Product)
+		}
+		s.mu.Unlock()
+		forgetProductMetrics(d.Product)
+
+	default:
+		if a := s.lockAuction(e.Product()); a != nil {
+			a.apply(e)
+			a.mu.Unlock()
+		}
+	}
+}
+
+// Forwarder hands the calls a follower may not serve to the cluster's
+// leader, so clients can talk to any node
+type Forwarder struct {
+	node    *cluster.Node
+	limiter *RateLimiter
+	creds   credentials.TransportCredentials
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewForwarder creates a forwarder for node, dialing the leader with creds.
+// Forwarded calls name the client behind them, as the limiter sees it, in
+// x-forwarded-for, and the user its certificate identifies in
+// x-client-user.
+func NewForwarder(node *cluster.Node, limiter *RateLimiter, creds credentials.TransportCredentials) *Forwarder {
+	return &Forwarder{node: node, limiter: limiter, creds: creds, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// leader returns a connection to the current leader, or nil if this node
+// leads
+func (f *Forwarder) leader() (*grpc.ClientConn, error) {
+	if f.node.IsLeader() {
+		return nil, nil
+	}
+	leader, ok := f.node.Leader()
+	if !ok || leader.ID == f.node.Self().ID {
+		return nil, status.Error(codes.Unavailable, "no leader elected yet, try again shortly")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if conn, ok := f.conns[leader.Addr]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(leader.Addr,
+		grpc.WithTransportCredentials(f.creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cannot reach leader %s: %v", leader.ID, err)
+	}
+	f.conns[leader.Addr] = conn
+	return conn, nil
+}
+
+// outgoing carries a call's metadata over to the leader
+func (f *Forwarder) outgoing(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	// Drop transport headers the client connection sets itself
+	for key := range md {
+		if strings.HasPrefix(key, ":") || key == "content-type" || key == "user-agent" {
+			delete(md, key)
+		}
+	}
+	if addr := f.limiter.clientAddr(ctx); addr != "" {
+		md.Set("x-forwarded-for", addr)
+	}
+	md.Delete(clientUserKey)
+	if user := certUserFromContext(ctx); user != "" {
+		md.Set(clientUserKey, user)
+	}
+	// The leader logs the call under the same request ID
+	if id := logging.RequestID(ctx); id != "" {
+		md.Set(logging.MetadataKey, id)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// UnaryInterceptor forwards calls to the leader unless this node leads or
+// the call only reads replicated state
+func (f *Forwarder) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if followerReads[path.Base(info.FullMethod)] || !ownService(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	conn, err := f.leader()
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return handler(ctx, req)
+	}
+
+	_, out, err := methodTypes(info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	resp := out.New().Interface()
+	var header metadata.MD
+	err = conn.Invoke(f.outgoing(ctx), info.FullMethod, req, resp, grpc.Header(&header))
+	if len(header) > 0 {
+		grpc.SetHeader(ctx, header)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamInterceptor relays server streams from the leader unless a
+// follower may serve them
+func (f *Forwarder) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if followerReads[path.Base(info.FullMethod)] || !ownService(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	conn, err := f.leader()
+	if err != nil {
+		return err
+	}
+	if conn == nil || !info.IsServerStream || info.IsClientStream {
+		return handler(srv, ss)
+	}
+
+	in, out, err := methodTypes(info.FullMethod)
+	if err != nil {
+		return err
+	}
+	req := in.New().Interface()
+	if err := ss.RecvMsg(req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(f.outgoing(ss.Context()))
+	defer cancel()
+	cs, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if err := cs.SendMsg(req); err != nil {
+		return err
+	}
+	if err := cs.CloseSend(); err != nil {
+		return err
+	}
+	// Pass on the headers the leader sends once subscribed; a stream without
+	// them failed, which receiving reports
+	if header, _ := cs.Header(); header != nil {
+		if err := ss.SendHeader(header); err != nil {
+			return err
+		}
+	}
+	for {
+		msg := out.New().Interface()
+		if err := cs.RecvMsg(msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := ss.SendMsg(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// methodTypes looks up the request and response types of a gRPC method
+func methodTypes(fullMethod string) (in, out protoreflect.MessageType, err error) {
+	service, method := path.Split(strings.TrimPrefix(fullMethod, "/"))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(strings.TrimSuffix(service, "/")))
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Unimplemented, "unknown service of %s", fullMethod)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, nil, status.Errorf(codes.Unimplemented, "unknown service of %s", fullMethod)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, nil, status.Errorf(codes.Unimplemented, "unknown method %s", fullMethod)
+	}
+	if in, err = protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName()); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "%s: %v", fullMethod, err)
+	}
+	if out, err = protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName()); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "%s: %v", fullMethod, err)
+	}
+	return in, out, nil
+}
+
+// peerHosts returns the hosts of a cluster's nodes, which forward calls
+// for their clients
+func peerHosts(peers []cluster.Peer) []string {
+	var hosts []string
+	for _, p := range peers {
+		if host, _, err := net.SplitHostPort(p.Addr); err == nil {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
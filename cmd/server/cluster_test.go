@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/cluster"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"github.com/930r91na/Subasta-grpc/pkg/ratelimit"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"github.com/930r91na/Subasta-grpc/pkg/webhook"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+// testNode is one server of a cluster running in the test
+type testNode struct {
+	server *AuctionServer
+	node   *cluster.Node
+	client pb.AuctionServiceClient
+	stop   func()
+}
+
+// freeAddr returns a loopback address nothing listens on
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+// startCluster starts n servers replicating over loopback, each serving
+// gRPC with followers forwarding writes to the leader as in main
+func startCluster(t *testing.T, n int) []*testNode {
+	t.Helper()
+	listeners := make([]net.Listener, n)
+	peers := make([]cluster.Peer, n)
+	for i := range peers {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		listeners[i] = lis
+		peers[i] = cluster.Peer{ID: fmt.Sprintf("n%d", i+1), Addr: lis.Addr().String(), RaftAddr: freeAddr(t)}
+	}
+	limits, err := ratelimit.ParseLimits("*=off")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := make([]*testNode, n)
+	for i, peer := range peers {
+		dir := t.TempDir()
+		eventLog, err := events.Open(filepath.Join(dir, "events.log"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		inbox := notify.NewInbox()
+		webhooks, err := webhook.NewDispatcher(webhook.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		roles := rbac.NewStore(rbac.DefaultPolicy)
+		s := NewAuctionServer(eventLog, ledger.DefaultSchedule, inbox, webhooks, nil, roles)
+		node, err := s.joinCluster(cluster.Config{NodeID: peer.ID, Peers: peers, Dir: filepath.Join(dir, "raft")})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		forwarder := NewForwarder(node, NewRateLimiter(limits, nil), insecure.NewCredentials())
+		srv := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(forwarder.UnaryInterceptor),
+			grpc.ChainStreamInterceptor(forwarder.StreamInterceptor),
+		)
+		pb.RegisterAuctionServiceServer(srv, s)
+		go srv.Serve(listeners[i])
+
+		conn, err := grpc.NewClient(peer.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stopped := false
+		tn := &testNode{server: s, node: node, client: pb.NewAuctionServiceClient(conn)}
+		tn.stop = func() {
+			if stopped {
+				return
+			}
+			stopped = true
+			s.endStreams()
+			srv.Stop()
+			conn.Close()
+			if err := node.Close(); err != nil {
+				t.Errorf("closing %s: %v", peer.ID, err)
+			}
+			webhooks.Stop()
+			eventLog.Close()
+		}
+		t.Cleanup(tn.stop)
+		nodes[i] = tn
+	}
+	return nodes
+}
+
+// waitLeader waits until one of the running nodes leads and returns it
+func waitLeader(t *testing.T, nodes []*testNode) *testNode {
+	t.Helper()
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, n := range nodes {
+			if n.node.IsLeader() {
+				return n
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("no leader elected")
+	return nil
+}
+
+// clusterState reads what the test compares across leaders
+func clusterState(t *testing.T, client pb.AuctionServiceClient) []proto.Message {
+	t.Helper()
+	ctx := context.Background()
+	catalog, err := client.GetCatalog(ctx, &pb.GetCatalogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The catalog comes in no particular order
+	products := catalog.GetProducts()
+	sort.Slice(products, func(i, j int) bool { return products[i].GetProduct() < products[j].GetProduct() })
+	state := []proto.Message{catalog}
+	for _, account := range []string{"Alice", "Bob", "seller", string(ledger.HouseCommission)} {
+		statement, err := client.GetStatement(ctx, &pb.GetStatementRequest{Account: account})
+		if err != nil {
+			t.Fatal(err)
+		}
+		state = append(state, statement)
+	}
+	for _, user := range []string{"Alice", "Bob", "seller"} {
+		inbox, err := client.ListNotifications(ctx, &pb.ListNotificationsRequest{User: user})
+		if err != nil {
+			t.Fatal(err)
+		}
+		state = append(state, inbox)
+	}
+	return state
+}
+
+// TestClusterFailover places bids through the leader and a follower, takes
+// the leader down, and checks that the node taking over has the same
+// catalog, ledger and notifications, and goes on accepting bids
+func TestClusterFailover(t *testing.T) {
+	if testing.Short() {
+		t.Skip("elects leaders over loopback")
+	}
+	nodes := startCluster(t, 3)
+	leader := waitLeader(t, nodes)
+	var follower *testNode
+	for _, n := range nodes {
+		if n != leader {
+			follower = n
+			break
+		}
+	}
+	ctx := context.Background()
+
+	for _, name := range []string{"seller", "Alice", "Bob"} {
+		if _, err := follower.client.RegisterUser(ctx, &pb.RegisterUserRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, product := range []string{"Laptop", "Phone"} {
+		resp, err := leader.client.AddProduct(ctx, &pb.AddProductRequest{Seller: "seller", Product: product, InitialPrice: 100})
+		if err != nil || !resp.GetSuccess() {
+			t.Fatalf("AddProduct(%s) = %v, %v", product, resp, err)
+		}
+	}
+	bids := []struct {
+		via     *testNode
+		buyer   string
+		product string
+		amount  float32
+	}{
+		{leader, "Alice", "Laptop", 150},
+		{follower, "Bob", "Laptop", 200},
+		{follower, "Alice", "Phone", 120},
+		{leader, "Alice", "Laptop", 250},
+		{follower, "Bob", "Phone", 130},
+	}
+	for _, b := range bids {
+		resp, err := b.via.client.PlaceBid(ctx, &pb.PlaceBidRequest{Buyer: b.buyer, Product: b.product, Amount: b.amount})
+		if err != nil || !resp.GetSuccess() {
+			t.Fatalf("PlaceBid(%s, %s, %.2f) = %v, %v", b.buyer, b.product, b.amount, resp, err)
+		}
+	}
+	resp, err := follower.client.CloseAuction(ctx, &pb.CloseAuctionRequest{Seller: "seller", Product: "Laptop"})
+	if err != nil || !resp.GetSuccess() {
+		t.Fatalf("CloseAuction = %v, %v", resp, err)
+	}
+
+	before := clusterState(t, leader.client)
+	leader.stop()
+
+	var rest []*testNode
+	for _, n := range nodes {
+		if n != leader {
+			rest = append(rest, n)
+		}
+	}
+	next := waitLeader(t, rest)
+	after := clusterState(t, next.client)
+	for i := range before {
+		if !proto.Equal(after[i], before[i]) {
+			t.Errorf("after failover:\n%v\nwant\n%v", after[i], before[i])
+		}
+	}
+
+	bid, err := next.client.PlaceBid(ctx, &pb.PlaceBidRequest{Buyer: "Alice", Product: "Phone", Amount: 140})
+	if err != nil || !bid.GetSuccess() {
+		t.Fatalf("PlaceBid on the new leader = %v, %v", bid, err)
+	}
+	// The remaining follower commits it under the next sequence number
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		a, b := rest[0].server.events.Events(), rest[1].server.events.Events()
+		if len(a) == len(b) {
+			if last := a[len(a)-1]; last.Seq != uint64(len(a)) || last.Type() != events.TypeBidPlaced {
+				t.Errorf("last event %d of %d is %s", last.Seq, len(a), last.Type())
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("nodes hold %d and %d events", len(a), len(b))
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
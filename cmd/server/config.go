@@ -0,0 +1,238 @@
+package main
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/930r91na/Subasta-grpc/pkg/cluster"
+	"github.com/930r91na/Subasta-grpc/pkg/config"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/ratelimit"
+	"github.com/930r91na/Subasta-grpc/pkg/tracing"
+)
+
+// Config holds the server's settings; see package config for how they are
+// loaded
+type Config struct {
+	Network networkConfig `yaml:"network"`
+	TLS     tlsConfig     `yaml:"tls"`
+	Storage storageConfig `yaml:"storage"`
+	Cluster clusterConfig `yaml:"cluster"`
+	Auction auctionConfig `yaml:"auction"`
+	Email   emailConfig   `yaml:"email"`
+	Staff   staffConfig   `yaml:"staff"`
+	Log     logConfig     `yaml:"log"`
+	Tracing tracingConfig `yaml:"tracing"`
+}
+
+// networkConfig is where the server listens and whom it trusts
+type networkConfig struct {
+	Addr            string        `yaml:"addr" flag:"addr" usage:"address to serve gRPC on"`
+	MetricsAddr     string        `yaml:"metrics_addr" flag:"metrics-addr" usage:"address to serve Prometheus metrics on at /metrics (disabled when empty)"`
+	TrustedProxies  string        `yaml:"trusted_proxies" flag:"trusted-proxies" usage:"comma separated addresses, such as the web server's, allowed to forward client addresses in x-forwarded-for (none when empty)"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" flag:"shutdown-timeout" usage:"how long calls in flight may run on after SIGTERM before they are cut off"`
+}
+
+// tlsConfig secures the server's connections
+type tlsConfig struct {
+	Cert       string `yaml:"cert" flag:"tls-cert" usage:"PEM certificate to serve gRPC and replicate with over TLS (plaintext when empty)"`
+	Key        string `yaml:"key" flag:"tls-key" usage:"PEM private key of the certificate"`
+	CA         string `yaml:"ca" flag:"tls-ca" usage:"PEM CA certificates that client certificates and the cluster's nodes are verified against"`
+	ClientAuth string `yaml:"client_auth" flag:"tls-client-auth" usage:"client certificates: none, request (verified when sent) or require"`
+	Proxies    string `yaml:"proxies" flag:"tls-proxies" usage:"comma separated names of certificates, such as the web server's, that may act for any user; the cluster's nodes always may"`
+}
+
+// storageConfig is where the server keeps its state
+type storageConfig struct {
+	Backend string `yaml:"backend" flag:"storage" usage:"where state is kept: \"file\" for files under data_dir, or \"memory\" to lose it on exit"`
+	DataDir string `yaml:"data_dir" flag:"data" usage:"directory for the event log and other persistent server data"`
+}
+
+// Storage backends
+const (
+	storageFile   = "file"
+	storageMemory = "memory"
+)
+
+// path returns where the file name is kept, or "" to keep it in memory
+func (c storageConfig) path(name string) string {
+	if c.Backend == storageMemory {
+		return ""
+	}
+	return filepath.Join(c.DataDir, name)
+}
+
+// clusterConfig places the server in a replicated cluster
+type clusterConfig struct {
+	Node  string `yaml:"node" flag:"node" usage:"ID of this node among the cluster's peers"`
+	Peers string `yaml:"peers" flag:"cluster" usage:"replicate with Raft across the nodes \"id=addr/raft-addr,...\", e.g. \"n1=127.0.0.1:50051/127.0.0.1:7051\" (standalone when empty)"`
+}
+
+// auctionConfig holds the rules of the auction
+type auctionConfig struct {
+	Commission        string        `yaml:"commission" flag:"commission" usage:"commission tiers as \"min=rate%[+flat]\", e.g. \"0=10%,1000=5%\""`
+	VerifiedThreshold float64       `yaml:"verified_threshold" flag:"verified-threshold" usage:"starting price from which listings need a verified seller"`
+	RateLimits        string        `yaml:"rate_limits" flag:"rate-limits" usage:"per-method limits for each authenticated user and client address as \"Method=calls/unit[:burst],...\", \"*\" for the rest"`
+	IdempotencyTTL    time.Duration `yaml:"idempotency_ttl" flag:"idempotency-ttl" usage:"how long responses are replayed to calls retried with the same idempotency key"`
+}
+
+// emailConfig is how notification emails are sent
+type emailConfig struct {
+	SMTP     string `yaml:"smtp" flag:"smtp" usage:"SMTP relay host:port for email notifications (disabled when empty)"`
+	From     string `yaml:"from" flag:"smtp-from" usage:"sender address of notification emails"`
+	User     string `yaml:"user" flag:"smtp-user" usage:"SMTP username"`
+	Password string `yaml:"password" flag:"smtp-password" env:"SMTP_PASSWORD" secret:"true" usage:"SMTP password of the user"`
+}
+
+// staffConfig holds the credentials of the staff
+type staffConfig struct {
+	AdminTokens string `yaml:"admin_tokens" flag:"admin-tokens" env:"AUCTION_ADMIN_TOKENS" secret:"true" usage:"credentials of staff granted the admin role, as \"name=token,...\""`
+	StaffTokens string `yaml:"staff_tokens" flag:"staff-tokens" env:"AUCTION_STAFF_TOKENS" secret:"true" usage:"credentials of other staff, whose roles are managed with GrantRole"`
+}
+
+// logConfig sets what is logged
+type logConfig struct {
+	Level string `yaml:"level" flag:"log-level" usage:"least severe level logged: debug, info, warn or error"`
+}
+
+// tracingConfig sets where traces go
+type tracingConfig struct {
+	Exporter string `yaml:"exporter" flag:"trace-exporter" usage:"where to send traces: \"stdout\", or \"otlp\" for the collector in $OTEL_EXPORTER_OTLP_ENDPOINT (disabled when empty)"`
+}
+
+// defaultConfig returns the settings of a server run on a laptop
+func defaultConfig() *Config {
+	cfg := &Config{}
+	cfg.Network.Addr = ":50051"
+	cfg.Network.MetricsAddr = ":9090"
+	cfg.Network.ShutdownTimeout = 10 * time.Second
+	cfg.Storage.Backend = storageFile
+	cfg.Storage.DataDir = "data"
+	cfg.Auction.Commission = ledger.DefaultSchedule.String()
+	cfg.Auction.VerifiedThreshold = 1000
+	cfg.Auction.RateLimits = defaultRateLimits
+	cfg.Auction.IdempotencyTTL = 24 * time.Hour
+	cfg.Email.From = "Auction House <auction@localhost>"
+	cfg.Log.Level = "info"
+	return cfg
+}
+
+// Validate reports every setting that is out of range or unreadable
+func (c *Config) Validate() error {
+	var errs config.Errors
+
+	if err := config.CheckAddr(c.Network.Addr); err != nil {
+		errs.Addf("network.addr", "%v", err)
+	}
+	if c.Network.MetricsAddr != "" {
+		if err := config.CheckAddr(c.Network.MetricsAddr); err != nil {
+			errs.Addf("network.metrics_addr", "%v", err)
+		}
+	}
+	if c.Network.ShutdownTimeout <= 0 {
+		errs.Addf("network.shutdown_timeout", "must be positive")
+	}
+
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		errs.Addf("tls.key", "cert and key must be set together")
+	}
+	for _, f := range []struct{ key, file string }{
+		{"tls.cert", c.TLS.Cert}, {"tls.key", c.TLS.Key}, {"tls.ca", c.TLS.CA},
+	} {
+		if f.file == "" {
+			continue
+		}
+		if _, err := os.Stat(f.file); err != nil {
+			errs.Addf(f.key, "%v", err)
+		}
+	}
+	clientAuth, err := certs.ParseClientAuth(c.TLS.ClientAuth)
+	if err != nil {
+		errs.Addf("tls.client_auth", "%v", err)
+	}
+	if c.TLS.Cert == "" && (c.TLS.CA != "" || clientAuth != tls.NoClientCert) {
+		errs.Addf("tls.cert", "must be set to verify clients")
+	}
+	if c.TLS.CA == "" && clientAuth != tls.NoClientCert {
+		errs.Addf("tls.ca", "must be set to verify clients")
+	}
+	if c.TLS.Cert != "" && c.TLS.CA == "" && c.Cluster.Peers != "" {
+		errs.Addf("tls.ca", "must be set for the cluster's nodes to verify each other")
+	}
+
+	switch c.Storage.Backend {
+	case storageFile:
+		if c.Storage.DataDir == "" {
+			errs.Addf("storage.data_dir", "must be set")
+		}
+	case storageMemory:
+		if c.Cluster.Peers != "" {
+			errs.Addf("storage.backend", "must be file for the cluster's Raft log")
+		}
+	default:
+		errs.Addf("storage.backend", "%q is not file or memory", c.Storage.Backend)
+	}
+
+	if c.Cluster.Peers != "" {
+		peers, err := cluster.ParsePeers(c.Cluster.Peers)
+		if err != nil {
+			errs.Addf("cluster.peers", "%v", err)
+		}
+		found := false
+		for _, p := range peers {
+			found = found || p.ID == c.Cluster.Node
+		}
+		if err == nil && !found {
+			errs.Addf("cluster.node", "%q is not one of the peers", c.Cluster.Node)
+		}
+	} else if c.Cluster.Node != "" {
+		errs.Addf("cluster.node", "set without cluster.peers")
+	}
+
+	if _, err := ledger.ParseSchedule(c.Auction.Commission); err != nil {
+		errs.Addf("auction.commission", "%v", err)
+	}
+	if c.Auction.VerifiedThreshold < 0 {
+		errs.Addf("auction.verified_threshold", "must not be negative")
+	}
+	if _, err := ratelimit.ParseLimits(c.Auction.RateLimits); err != nil {
+		errs.Addf("auction.rate_limits", "%v", err)
+	}
+	if c.Auction.IdempotencyTTL <= 0 {
+		errs.Addf("auction.idempotency_ttl", "must be positive")
+	}
+
+	if c.Email.SMTP != "" {
+		if err := config.CheckAddr(c.Email.SMTP); err != nil {
+			errs.Addf("email.smtp", "%v", err)
+		}
+	}
+	if c.Email.Password != "" && c.Email.User == "" {
+		errs.Addf("email.password", "set without email.user")
+	}
+
+	tokens := make(staffTokens)
+	if _, err := parseStaffTokens(tokens, c.Staff.AdminTokens); err != nil {
+		errs.Addf("staff.admin_tokens", "%v", err)
+	}
+	if _, err := parseStaffTokens(tokens, c.Staff.StaffTokens); err != nil {
+		errs.Addf("staff.staff_tokens", "%v", err)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs.Addf("log.level", "%v", err)
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs.Addf("tracing.exporter", "%q is not stdout or otlp", c.Tracing.Exporter)
+	}
+
+	return errs.Err()
+}
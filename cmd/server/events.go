@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/cluster"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// record appends a state change to the event log. Handlers validate a
+// command, record its event and only then apply the event to the live
+// state, so the log is never behind what clients have seen.
+func (s *AuctionServer) record(ctx context.Context, data events.Payload) (events.Event, error) {
+	_, span := tracer.Start(ctx, "recordEvent", trace.WithAttributes(attribute.String("event.type", string(data.Type()))))
+	defer span.End()
+
+	start := time.Now()
+	e, err := s.events.Append(data)
+	eventSeconds.WithLabelValues(string(data.Type())).Observe(time.Since(start).Seconds())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "event not recorded")
+	}
+	if errors.Is(err, cluster.ErrNotLeader) {
+		return events.Event{}, status.Error(codes.Unavailable, "this node lost leadership, try again")
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record event", "type", data.Type(), "error", err)
+		return events.Event{}, status.Errorf(codes.Internal, "failed to record %s", data.Type())
+	}
+	span.SetAttributes(attribute.Int64("event.seq", int64(e.Seq)))
+	return e, nil
+}
+
+// apply folds one of the auction's events into it and publishes the new
+// state to readers; callers hold a.mu
+func (a *auction) apply(e events.Event) {
+	a.Apply(e)
+	a.commit()
+}
+
+// applyRoles folds a role event into the role store. The store is kept in
+// memory only, rebuilt from the event log when the server starts.
+func (s *AuctionServer) applyRoles(e events.Event) {
+	var err error
+	switch d := e.Data.(type) {
+	case *events.UserRegistered:
+		_, err = s.roles.Grant(d.Name, rbac.DefaultRoles...)
+	case *events.RoleGranted:
+		_, err = s.roles.Grant(d.User, rbac.Role(d.Role))
+	case *events.RoleRevoked:
+		s.roles.Revoke(d.User, rbac.Role(d.Role))
+	}
+	if err != nil {
+		// Granted roles were known to the policy when they were recorded
+		slog.Error("Failed to apply role event", "seq", e.Seq, "error", err)
+	}
+}
+
+// restore rebuilds the users, their roles and the catalog by replaying the
+// event log
+func (s *AuctionServer) restore() {
+	st := events.NewState()
+	s.events.Replay(st)
+	s.events.Replay(events.ProjectionFunc(s.applyRoles))
+
+	s.users = st.Users
+	for name, p := range st.Products {
+		a := &auction{Product: p}
+		a.commit()
+		s.products[name] = a
+	}
+	if st.Seq > 0 {
+		slog.Info("Restored state from the event log", "users", len(s.users), "products", len(s.products), "events", st.Seq)
+	}
+}
+
+// ledgerProjection books sales and refunds as auctions close and sold
+// listings are removed
+type ledgerProjection struct {
+	ledger *ledger.Ledger
+	// sold are the products booked as sales
+	sold map[string]bool
+}
+
+func newLedgerProjection(l *ledger.Ledger) *ledgerProjection {
+	return &ledgerProjection{ledger: l, sold: make(map[string]bool)}
+}
+
+// Apply implements events.Projection
+func (p *ledgerProjection) Apply(e events.Event) {
+	switch d := e.Data.(type) {
+	case *events.AuctionClosed:
+		if d.Winner == "" {
+			return
+		}
+		_, err := p.ledger.RecordSale(ledger.Sale{
+			Product:    d.Product,
+			Buyer:      d.Winner,
+			Seller:     d.Seller,
+			Price:      ledger.Cents(d.Price),
+			Commission: d.Commission,
+			Time:       e.Time,
+		})
+		if err != nil {
+			// The ledger only rejects unbalanced entries, which would be a bug here
+			slog.Error("Failed to record sale", "product", d.Product, "seq", e.Seq, "error", err)
+			return
+		}
+		p.sold[d.Product] = true
+
+	case *events.ProductRemoved:
+		if !p.sold[d.Product] {
+			return
+		}
+		delete(p.sold, d.Product)
+		memo := "Refund for removed listing " + d.Product
+		if _, err := p.ledger.RefundSale(d.Product, memo, e.Time); err != nil && !errors.Is(err, ledger.ErrNothingToRefund) {
+			slog.Error("Failed to refund sale", "product", d.Product, "seq", e.Seq, "error", err)
+		}
+	}
+}
+
+// newAuction starts the live state of a newly listed product
+func newAuction(d *events.ProductListed) *auction {
+	a := &auction{Product: events.NewProduct(d)}
+	a.commit()
+	return a
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// feedKeep is how many changes to the catalog are kept for subscribers
+// resuming a stream
+const feedKeep = 1000
+
+// feedBuffer is how many changes a subscriber may fall behind by before its
+// stream is ended
+const feedBuffer = 256
+
+// SubscribeAuctionEvents streams the changes to the catalog, replaying
+// those after the requested sequence number first, until the client goes
+// away or the server shuts down. Every node keeps the feed, so followers
+// serve it themselves.
+func (s *AuctionServer) SubscribeAuctionEvents(req *pb.SubscribeAuctionEventsRequest, stream pb.AuctionService_SubscribeAuctionEventsServer) error {
+	backlog, sub, err := s.feed.Subscribe(req.GetAfterSeq(), feedBuffer)
+	if errors.Is(err, events.ErrFeedGap) {
+		return status.Errorf(codes.OutOfRange,
+			"changes after %d are no longer kept, reload the catalog and subscribe from 0", req.GetAfterSeq())
+	}
+	defer sub.Cancel()
+
+	// The headers tell the client it is subscribed, before any change comes
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	slog.InfoContext(stream.Context(), "Client subscribed to auction events", "after_seq", req.GetAfterSeq(), "backlog", len(backlog))
+	for _, c := range backlog {
+		if err := stream.Send(toProtoAuctionEvent(c)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server shutting down, subscribe again")
+		case c, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted,
+					"client fell behind, subscribe again after the last event received")
+			}
+			if err := stream.Send(toProtoAuctionEvent(c)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toProtoAuctionEvent(c events.Change) *pb.AuctionEvent {
+	return &pb.AuctionEvent{
+		Seq:     c.Event.Seq,
+		Type:    string(c.Event.Type()),
+		Product: c.Event.Product(),
+		Info:    c.Info,
+		Time:    timestamppb.New(c.Event.Time),
+	}
+}
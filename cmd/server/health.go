@@ -0,0 +1,55 @@
+package main
+
+import (
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/cluster"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthServices are the services whose health is reported; "" stands for
+// the server as a whole
+var healthServices = []string{
+	"",
+	pb.AuctionService_ServiceDesc.ServiceName,
+	pb.AuctionAdminService_ServiceDesc.ServiceName,
+}
+
+// healthPollInterval is how often a cluster node checks for a leader
+const healthPollInterval = time.Second
+
+// setHealth reports every service with the given status
+func setHealth(h *health.Server, serving healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range healthServices {
+		h.SetServingStatus(service, serving)
+	}
+}
+
+// watchHealth reports the services as serving while events can be stored.
+// A standalone server's event log is open and its state restored before
+// it is called, so it serves right away; a cluster node serves while a
+// leader is elected to commit events, and reports not serving otherwise.
+func watchHealth(h *health.Server, node *cluster.Node, done <-chan struct{}) {
+	if node == nil {
+		setHealth(h, healthpb.HealthCheckResponse_SERVING)
+		return
+	}
+
+	setHealth(h, healthpb.HealthCheckResponse_NOT_SERVING)
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+	for {
+		if _, ok := node.Leader(); ok {
+			setHealth(h, healthpb.HealthCheckResponse_SERVING)
+		} else {
+			setHealth(h, healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
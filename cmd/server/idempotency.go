@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"path"
+
+	"github.com/930r91na/Subasta-grpc/pkg/idempotency"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// idempotentMethods are the mutating calls that honor an idempotency key
+var idempotentMethods = map[string]bool{
+	"RegisterUser":  true,
+	"UpdateProfile": true,
+	"AddProduct":    true,
+	"PlaceBid":      true,
+	"CloseAuction":  true,
+}
+
+// maxIdempotencyKeyLen bounds the keys clients may send
+const maxIdempotencyKeyLen = 255
+
+// maxIdempotencyKeys bounds how many responses are remembered at once
+const maxIdempotencyKeys = 100000
+
+// Deduplicator replays the original response when a mutating call is
+// retried with the same idempotency-key metadata. Responses are remembered
+// by this process only: a call retried after the server restarts, or
+// against the node that took over from a failed leader, runs again.
+type Deduplicator struct {
+	store *idempotency.Store
+}
+
+// NewDeduplicator creates a deduplicator remembering responses for the
+// lifetime of store
+func NewDeduplicator(store *idempotency.Store) *Deduplicator {
+	return &Deduplicator{store: store}
+}
+
+// UnaryInterceptor runs a keyed call at most once. Keys are scoped to the
+// method and acting user, and a replayed response is flagged with an
+// idempotent-replayed header. Calls without a key run as usual.
+func (d *Deduplicator) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+	if !idempotentMethods[method] {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get("idempotency-key")
+	if len(keys) == 0 || keys[0] == "" {
+		return handler(ctx, req)
+	}
+	if len(keys[0]) > maxIdempotencyKeyLen {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency key longer than %d bytes", maxIdempotencyKeyLen)
+	}
+
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req.(proto.Message))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fingerprint request: %v", err)
+	}
+	fingerprint := sha256.Sum256(body)
+
+	scope := method + "\x00" + subjectOf(ctx, info.FullMethod, req) + "\x00" + keys[0]
+	resp, replayed, err := d.store.Do(scope, fingerprint[:], func() (interface{}, error) {
+		return handler(ctx, req)
+	})
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs("idempotent-replayed", "true"))
+	}
+	return resp, err
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// clientUserKey is the metadata in which a proxy names the user whose
+// certificate it verified
+const clientUserKey = "x-client-user"
+
+// CertIdentity holds clients that present a certificate to the user it was
+// issued to: such a client may only act for that user. Clients without a
+// certificate are left to the rest of the chain.
+type CertIdentity struct {
+	// proxies, such as the web server and the cluster's nodes, act for
+	// other users and name the one they verified, if any, in x-client-user
+	proxies map[string]bool
+}
+
+// NewCertIdentity creates the identity check, trusting the certificates
+// named in proxies to act for any user
+func NewCertIdentity(proxies []string) *CertIdentity {
+	trusted := make(map[string]bool)
+	for _, name := range proxies {
+		if name = strings.TrimSpace(name); name != "" {
+			trusted[name] = true
+		}
+	}
+	return &CertIdentity{proxies: trusted}
+}
+
+type certUserContextKey struct{}
+
+// certUserFromContext returns the user a call's certificate identifies
+func certUserFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(certUserContextKey{}).(string)
+	return user
+}
+
+// certUser returns the user identified by the certificate behind a call, or
+// "" if there is none
+func (c *CertIdentity) certUser(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+	name := certs.PeerName(info.State)
+	if !c.proxies[name] {
+		return name
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if users := md.Get(clientUserKey); len(users) > 0 {
+		return users[0]
+	}
+	return ""
+}
+
+// check rejects a request acting for someone other than user
+func (c *CertIdentity) check(user, method string, req interface{}) error {
+	if !strings.HasPrefix(method, auctionServicePrefix) {
+		return nil
+	}
+	acting, ok := actingUsers[strings.TrimPrefix(method, auctionServicePrefix)]
+	if !ok {
+		return nil
+	}
+	if name := acting(req); name != user {
+		return status.Errorf(codes.PermissionDenied, "certificate of %s cannot act for %q", user, name)
+	}
+	return nil
+}
+
+// UnaryInterceptor checks unary calls against their certificate
+func (c *CertIdentity) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	user := c.certUser(ctx)
+	if user == "" {
+		return handler(ctx, req)
+	}
+	if err := c.check(user, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, certUserContextKey{}, user), req)
+}
+
+// StreamInterceptor checks the requests of streams against their
+// certificate as they are received
+func (c *CertIdentity) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	user := c.certUser(ss.Context())
+	if user == "" {
+		return handler(srv, ss)
+	}
+	ctx := context.WithValue(ss.Context(), certUserContextKey{}, user)
+	return handler(srv, &identityStream{
+		ServerStream: &contextStream{ServerStream: ss, ctx: ctx},
+		identity:     c,
+		user:         user,
+		method:       info.FullMethod,
+	})
+}
+
+// identityStream checks every request received on a stream
+type identityStream struct {
+	grpc.ServerStream
+	identity *CertIdentity
+	user     string
+	method   string
+}
+
+func (s *identityStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.identity.check(s.user, s.method, m)
+}
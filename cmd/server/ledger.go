@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CloseAuction ends an auction and books the sale to the highest bidder
+func (s *AuctionServer) CloseAuction(ctx context.Context, req *pb.CloseAuctionRequest) (*pb.CloseAuctionResponse, error) {
+	product := req.GetProduct()
+
+	a := s.lockAuction(product)
+	if a == nil {
+		return &pb.CloseAuctionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Product %s does not exist", product),
+		}, nil
+	}
+	defer a.mu.Unlock()
+	productInfo := a.Info
+
+	if productInfo.Seller != req.GetSeller() {
+		return &pb.CloseAuctionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Only %s can close the auction for %s", productInfo.Seller, product),
+		}, nil
+	}
+
+	if productInfo.Closed {
+		return &pb.CloseAuctionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Auction for %s is already closed", product),
+		}, nil
+	}
+
+	if productInfo.Frozen {
+		return &pb.CloseAuctionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Auction for %s is frozen by a moderator", product),
+		}, nil
+	}
+
+	return s.closeAuction(ctx, a, "", "")
+}
+
+// closeAuction records an auction closing, tells everyone involved and
+// books the sale to the highest bidder. moderator names who forced it
+// closed, if anyone did, and why. Callers hold a.mu.
+func (s *AuctionServer) closeAuction(ctx context.Context, a *auction, moderator, reason string) (*pb.CloseAuctionResponse, error) {
+	productInfo := a.Info
+	product := productInfo.Product
+
+	closed := &events.AuctionClosed{
+		Product: product,
+		Seller:  productInfo.Seller,
+		Winner:  productInfo.HighestBidder,
+		Price:   productInfo.CurrentPrice,
+		Actor:   moderator,
+		Reason:  reason,
+	}
+	if closed.Winner != "" {
+		closed.Commission = s.commission.Commission(ledger.Cents(closed.Price))
+	}
+	// The ledger books the sale and everyone involved is notified as
+	// projections of this event
+	e, err := s.record(ctx, closed)
+	if err != nil {
+		return nil, err
+	}
+	a.apply(e)
+	s.publish(eventAuctionClosed, map[string]interface{}{
+		"seller":      productInfo.Seller,
+		"product":     product,
+		"winner":      productInfo.HighestBidder,
+		"final_price": productInfo.CurrentPrice,
+	})
+
+	winner := productInfo.HighestBidder
+	if winner == "" {
+		slog.InfoContext(ctx, "Auction closed without bids", "product", product)
+		return &pb.CloseAuctionResponse{
+			Success: true,
+			Message: fmt.Sprintf("Auction for %s closed without bids", product),
+		}, nil
+	}
+
+	slog.InfoContext(ctx, "Auction closed", "product", product, "winner", winner, "price", productInfo.CurrentPrice)
+	return &pb.CloseAuctionResponse{
+		Success:    true,
+		Message:    fmt.Sprintf("%s sold to %s for %.2f", product, winner, productInfo.CurrentPrice),
+		Winner:     winner,
+		FinalPrice: productInfo.CurrentPrice,
+	}, nil
+}
+
+// GetStatement returns the ledger statement of a user or house account
+func (s *AuctionServer) GetStatement(ctx context.Context, req *pb.GetStatementRequest) (*pb.GetStatementResponse, error) {
+	st := s.ledger.Statement(ledger.ParseAccount(req.GetAccount()))
+
+	lines := make([]*pb.StatementLine, 0, len(st.Lines))
+	for _, l := range st.Lines {
+		lines = append(lines, &pb.StatementLine{
+			EntryId:      l.EntryID,
+			Time:         timestamppb.New(l.Time),
+			Kind:         string(l.Kind),
+			Product:      l.Product,
+			Memo:         l.Memo,
+			AmountCents:  l.Amount,
+			BalanceCents: l.Balance,
+			Amount:       ledger.FormatCents(l.Amount),
+			Balance:      ledger.FormatCents(l.Balance),
+		})
+	}
+
+	return &pb.GetStatementResponse{
+		Account:      string(st.Account),
+		Lines:        lines,
+		BalanceCents: st.Balance,
+		Balance:      ledger.FormatCents(st.Balance),
+	}, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fatal logs an error the server cannot start with and exits
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// RequestLogger names every call with a request ID and logs it once it is
+// answered. Calls from the web server or another node keep the ID they
+// carry in x-request-id metadata.
+type RequestLogger struct {
+	limiter *RateLimiter
+}
+
+// NewRequestLogger creates a request logger naming clients as limiter does
+func NewRequestLogger(limiter *RateLimiter) *RequestLogger {
+	return &RequestLogger{limiter: limiter}
+}
+
+// withRequestID returns the context of a call, named by its request ID
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(logging.MetadataKey); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	return logging.WithRequestID(ctx, logging.AcceptRequestID(id))
+}
+
+// quietServices are polled by probes and tools, so their calls are logged
+// at debug level unless they fail
+var quietServices = map[string]bool{
+	healthpb.Health_ServiceDesc.ServiceName: true,
+}
+
+// logCall logs a finished call. Failures of the server itself are errors;
+// calls rejected for the caller's sake are not.
+func (l *RequestLogger) logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch {
+	case code == codes.Internal || code == codes.Unknown || code == codes.DataLoss:
+		level = slog.LevelError
+	case code == codes.OK && quietServices[strings.TrimPrefix(path.Dir(method), "/")]:
+		level = slog.LevelDebug
+	}
+
+	attrs := []any{
+		"method", path.Base(method),
+		"code", code.String(),
+		"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, "peer", p.Addr.String())
+	}
+	if client := l.limiter.clientAddr(ctx); client != "" {
+		attrs = append(attrs, "client", client)
+	}
+	if err != nil {
+		attrs = append(attrs, "error", status.Convert(err).Message())
+	}
+	slog.Log(ctx, level, "Call handled", attrs...)
+}
+
+// UnaryInterceptor logs unary calls
+func (l *RequestLogger) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = withRequestID(ctx)
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	l.logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamInterceptor logs streams once they end
+func (l *RequestLogger) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withRequestID(ss.Context())
+	start := time.Now()
+	err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	l.logCall(ctx, info.FullMethod, start, err)
+	return err
+}
+
+// contextStream is a server stream with a different context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
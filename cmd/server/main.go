@@ -2,177 +2,707 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/audit"
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/930r91na/Subasta-grpc/pkg/cluster"
+	"github.com/930r91na/Subasta-grpc/pkg/config"
+	"github.com/930r91na/Subasta-grpc/pkg/email"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/idempotency"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/logging"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"github.com/930r91na/Subasta-grpc/pkg/ratelimit"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"github.com/930r91na/Subasta-grpc/pkg/tracing"
+	"github.com/930r91na/Subasta-grpc/pkg/webhook"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// AuctionServer implements the gRPC service
+// tracer starts the spans inside calls; the calls themselves are traced by
+// the gRPC stats handler
+var tracer = otel.Tracer("github.com/930r91na/Subasta-grpc/cmd/server")
+
+// AuctionServer implements the gRPC service. Every change to the users and
+// catalog is recorded in the event log first; the maps below are the live
+// state folded from it.
 type AuctionServer struct {
 	pb.UnimplementedAuctionServiceServer
+
+	// mu guards the catalog itself. Each auction has its own lock, so bids
+	// on different products never wait for each other. Locks are taken in
+	// the order mu, auction, usersMu, and the event log's last.
 	mu       sync.RWMutex
-	users    map[string]string
-	products map[string]*pb.ProductInfo
-	bids     map[string]*pb.BidInfo
+	products map[string]*auction
+	// listing are the products whose listing is being recorded, so no
+	// one else lists them meanwhile
+	listing map[string]bool
+
+	usersMu sync.RWMutex
+	users   map[string]*pb.User
+	// registering are the names whose registration is being recorded
+	registering map[string]bool
+
+	events     *events.Log
+	history    *events.History
+	feed       *events.Feed
+	ledger     *ledger.Ledger
+	commission ledger.Schedule
+	inbox      *notify.Inbox
+	webhooks   *webhook.Dispatcher
+	roles      *rbac.Store
+	audit      *audit.Log
+
+	// node is this server's member of a cluster, once clustered is set
+	// and it has joined
+	clustered bool
+	node      atomic.Pointer[cluster.Node]
+
+	// done is closed when the server shuts down, ending open streams
+	done chan struct{}
+}
+
+// auction is the live state of one listing, guarded by its own lock
+type auction struct {
+	mu sync.Mutex
+	*events.Product
+	// removed is set when a moderator deletes the listing, for callers that
+	// found the auction before it left the catalog
+	removed bool
+
+	// snapshot is an immutable copy of Info for readers, replaced after
+	// every change; it is nil once the listing is removed
+	snapshot atomic.Pointer[pb.ProductInfo]
+}
+
+// commit publishes the current state of the auction to readers; callers
+// hold a.mu and call it after every change to a.Info
+func (a *auction) commit() {
+	a.snapshot.Store(proto.Clone(a.Info).(*pb.ProductInfo))
 }
 
-// NewAuctionServer creates a new auction server instance
-func NewAuctionServer() *AuctionServer {
-	return &AuctionServer{
-		users:    make(map[string]string),
-		products: make(map[string]*pb.ProductInfo),
-		bids:     make(map[string]*pb.BidInfo),
+// NewAuctionServer creates a new auction server instance, restoring its
+// state from the events already in log. mailer may be nil to disable email
+// notifications.
+func NewAuctionServer(eventLog *events.Log, commission ledger.Schedule, inbox *notify.Inbox, webhooks *webhook.Dispatcher, mailer *email.Notifier, roles *rbac.Store) *AuctionServer {
+	s := &AuctionServer{
+		users:       make(map[string]*pb.User),
+		registering: make(map[string]bool),
+		products:    make(map[string]*auction),
+		listing:     make(map[string]bool),
+		events:      eventLog,
+		history:     events.NewHistory(),
+		feed:        events.NewFeed(feedKeep),
+		ledger:      ledger.New(),
+		commission:  commission,
+		inbox:       inbox,
+		webhooks:    webhooks,
+		roles:       roles,
+		audit:       audit.New(),
+		done:        make(chan struct{}),
 	}
+	s.restore()
+	eventLog.Project(newLedgerProjection(s.ledger))
+	eventLog.Project(s.history)
+	eventLog.Project(s.feed)
+	eventLog.Project(auditProjection{s.audit})
+	// Notifications raised again from the events already recorded were
+	// emailed when first raised
+	notes := newNotifications(inbox, mailer)
+	eventLog.Project(notes)
+	notes.mailing = s.mailing
+	return s
+}
+
+// endStreams ends the streams of every client, which would otherwise hold
+// up a graceful stop for as long as the clients stay
+func (s *AuctionServer) endStreams() {
+	close(s.done)
+}
+
+// lockAuction finds a product's auction and locks it, or returns nil if the
+// product does not exist
+func (s *AuctionServer) lockAuction(product string) *auction {
+	s.mu.RLock()
+	a, exists := s.products[product]
+	s.mu.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	start := time.Now()
+	a.mu.Lock()
+	lockWaitSeconds.Observe(time.Since(start).Seconds())
+	if a.removed {
+		a.mu.Unlock()
+		return nil
+	}
+	return a
+}
+
+// auctions returns every auction in the catalog, unlocked
+func (s *AuctionServer) auctions() []*auction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*auction, 0, len(s.products))
+	for _, a := range s.products {
+		list = append(list, a)
+	}
+	return list
 }
 
 // RegisterUser registers a new user in the system
 func (s *AuctionServer) RegisterUser(ctx context.Context, req *pb.RegisterUserRequest) (*pb.RegisterUserResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	name := req.GetName()
 
-	if _, exists := s.users[name]; !exists {
-		log.Printf("Adding new user: %s", name)
-		s.users[name] = name
+	s.usersMu.Lock()
+	if _, exists := s.users[name]; exists || s.registering[name] {
+		s.usersMu.Unlock()
 		return &pb.RegisterUserResponse{
-			Success: true,
-			Message: fmt.Sprintf("User %s registered successfully", name),
+			Success: false,
+			Message: fmt.Sprintf("User %s already exists", name),
 		}, nil
 	}
+	s.registering[name] = true
+	s.usersMu.Unlock()
+
+	// Other users are read and updated while the event is recorded
+	e, err := s.record(ctx, &events.UserRegistered{Name: name})
+	s.usersMu.Lock()
+	delete(s.registering, name)
+	if err == nil {
+		events.ApplyUser(s.users, e)
+	}
+	s.usersMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
+	slog.InfoContext(ctx, "User registered", "user", name)
+	s.applyRoles(e)
+	s.publish(eventUserRegistered, map[string]interface{}{
+		"name": name,
+	})
 	return &pb.RegisterUserResponse{
-		Success: false,
-		Message: fmt.Sprintf("User %s already exists", name),
+		Success: true,
+		Message: fmt.Sprintf("User %s registered successfully", name),
 	}, nil
 }
 
 // AddProduct adds a product for sale
 func (s *AuctionServer) AddProduct(ctx context.Context, req *pb.AddProductRequest) (*pb.AddProductResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	product := req.GetProduct()
 
-	if _, exists := s.products[product]; !exists {
-		log.Printf("Adding new product: %s", product)
-		s.products[product] = &pb.ProductInfo{
-			Seller:       req.GetSeller(),
-			Product:      product,
-			InitialPrice: req.GetInitialPrice(),
-			CurrentPrice: req.GetInitialPrice(),
-		}
+	if s.isSuspended(req.GetSeller()) {
+		return &pb.AddProductResponse{
+			Success: false,
+			Message: fmt.Sprintf("User %s is suspended", req.GetSeller()),
+		}, nil
+	}
+
+	s.mu.Lock()
+	if _, exists := s.products[product]; exists || s.listing[product] {
+		s.mu.Unlock()
 		return &pb.AddProductResponse{
-			Success: true,
-			Message: fmt.Sprintf("Product %s added successfully", product),
+			Success: false,
+			Message: fmt.Sprintf("Product %s already exists", product),
 		}, nil
 	}
+	s.listing[product] = true
+	s.mu.Unlock()
 
+	// The catalog is not locked while the event is recorded, so bids on
+	// other products go on; the auction joins it once recorded, as a bid
+	// is applied
+	listed := &events.ProductListed{
+		Seller:       req.GetSeller(),
+		Product:      product,
+		InitialPrice: req.GetInitialPrice(),
+	}
+	_, err := s.record(ctx, listed)
+	s.mu.Lock()
+	delete(s.listing, product)
+	if err == nil {
+		s.products[product] = newAuction(listed)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Product listed", "product", product, "seller", listed.Seller)
+	s.publish(eventProductListed, map[string]interface{}{
+		"seller":        req.GetSeller(),
+		"product":       product,
+		"initial_price": req.GetInitialPrice(),
+	})
 	return &pb.AddProductResponse{
-		Success: false,
-		Message: fmt.Sprintf("Product %s already exists", product),
+		Success: true,
+		Message: fmt.Sprintf("Product %s added successfully", product),
 	}, nil
 }
 
 // PlaceBid places a bid on a product
 func (s *AuctionServer) PlaceBid(ctx context.Context, req *pb.PlaceBidRequest) (*pb.PlaceBidResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	product := req.GetProduct()
 	buyer := req.GetBuyer()
 	amount := req.GetAmount()
 
-	productInfo, exists := s.products[product]
-	if !exists {
+	a := s.lockAuction(product)
+	if a == nil {
+		bidRejected(product, bidUnknownProduct)
+		slog.InfoContext(ctx, "Bid rejected", "buyer", buyer, "product", product, "amount", amount, "reason", bidUnknownProduct)
 		return &pb.PlaceBidResponse{
 			Success: false,
 			Message: fmt.Sprintf("Product %s does not exist", product),
 		}, nil
 	}
+	defer a.mu.Unlock()
+	productInfo := a.Info
 
-	// Check if bid is higher than current price (updatePrice logic)
-	if amount > productInfo.CurrentPrice {
-		productInfo.CurrentPrice = amount
-
-		// Store the bid
-		key := product + buyer
-		s.bids[key] = &pb.BidInfo{
-			Buyer:   buyer,
-			Product: product,
-			Amount:  amount,
-		}
+	_, span := tracer.Start(ctx, "validateBid", trace.WithAttributes(
+		attribute.String("auction.product", product),
+		attribute.String("auction.buyer", buyer),
+		attribute.Float64("auction.amount", float64(amount)),
+	))
+	reason, resp, err := s.validateBid(req, productInfo)
+	if reason != "" {
+		span.SetAttributes(attribute.String("auction.rejected", reason))
+	}
+	span.End()
+	if reason != "" {
+		bidRejected(product, reason)
+		slog.InfoContext(ctx, "Bid rejected", "buyer", buyer, "product", product, "amount", amount,
+			"reason", reason, "price", productInfo.CurrentPrice, "version", productInfo.Version)
+		return resp, err
+	}
 
-		log.Printf("Bid accepted: %s offers %.2f for %s", buyer, amount, product)
-		return &pb.PlaceBidResponse{
-			Success:      true,
-			Message:      fmt.Sprintf("Bid accepted for %.2f", amount),
-			CurrentPrice: productInfo.CurrentPrice,
-		}, nil
+	previous := productInfo.HighestBidder
+	e, err := s.record(ctx, &events.BidPlaced{Buyer: buyer, Product: product, Amount: amount})
+	if err != nil {
+		return nil, err
 	}
+	a.apply(e)
+	s.publish(eventBidPlaced, map[string]interface{}{
+		"buyer":           buyer,
+		"product":         product,
+		"amount":          amount,
+		"previous_bidder": previous,
+	})
 
+	bidAccepted(product)
+	slog.InfoContext(ctx, "Bid accepted", "buyer", buyer, "product", product, "amount", amount, "version", productInfo.Version, "seq", e.Seq)
 	return &pb.PlaceBidResponse{
-		Success:      false,
-		Message:      fmt.Sprintf("Bid must be higher than %.2f", productInfo.CurrentPrice),
+		Success:      true,
+		Message:      fmt.Sprintf("Bid accepted for %.2f", amount),
 		CurrentPrice: productInfo.CurrentPrice,
+		Version:      productInfo.Version,
 	}, nil
 }
 
-// GetCatalog returns all products in the catalog
+// validateBid checks a bid against the product it is for. A rejected bid
+// comes back with the reason it is counted under and the answer to give.
+func (s *AuctionServer) validateBid(req *pb.PlaceBidRequest, productInfo *pb.ProductInfo) (string, *pb.PlaceBidResponse, error) {
+	product := req.GetProduct()
+	buyer := req.GetBuyer()
+
+	if err := checkSnapshot(req, productInfo); err != nil {
+		return bidStale, nil, err
+	}
+
+	rejected := func(reason, message string) (string, *pb.PlaceBidResponse, error) {
+		return reason, &pb.PlaceBidResponse{
+			Success:      false,
+			Message:      message,
+			CurrentPrice: productInfo.CurrentPrice,
+			Version:      productInfo.Version,
+		}, nil
+	}
+	if productInfo.Closed {
+		return rejected(bidClosed, fmt.Sprintf("Auction for %s is closed", product))
+	}
+	if productInfo.Frozen {
+		return rejected(bidFrozen, fmt.Sprintf("Auction for %s is frozen by a moderator", product))
+	}
+	if s.isSuspended(buyer) {
+		return rejected(bidSuspended, fmt.Sprintf("User %s is suspended", buyer))
+	}
+	// Check if bid is higher than current price (updatePrice logic)
+	if req.GetAmount() <= productInfo.CurrentPrice {
+		return rejected(bidTooLow, fmt.Sprintf("Bid must be higher than %.2f", productInfo.CurrentPrice))
+	}
+	return "", nil, nil
+}
+
+// checkSnapshot rejects a bid made against a version or price of the
+// product that is no longer current, so stale bids fail loudly
+func checkSnapshot(req *pb.PlaceBidRequest, productInfo *pb.ProductInfo) error {
+	stale := req.ExpectedVersion != nil && req.GetExpectedVersion() != productInfo.Version ||
+		req.ExpectedCurrentPrice != nil && req.GetExpectedCurrentPrice() != productInfo.CurrentPrice
+	if !stale {
+		return nil
+	}
+	return status.Errorf(codes.Aborted,
+		"%s changed since your snapshot: the price is now %.2f (version %d)",
+		productInfo.Product, productInfo.CurrentPrice, productInfo.Version)
+}
+
+// GetCatalog returns all products in the catalog. Each product is a
+// snapshot taken without waiting for bids in progress, and its version
+// matches the state it shows. With as_of, the catalog is read from history
+// as it was at that moment.
 func (s *AuctionServer) GetCatalog(ctx context.Context, req *pb.GetCatalogRequest) (*pb.GetCatalogResponse, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	if req.AsOf != nil {
+		t, err := asOf(req.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.GetCatalogResponse{
+			Products: s.history.Catalog(t),
+		}, nil
+	}
+
+	auctions := s.auctions()
 
-	products := make([]*pb.ProductInfo, 0, len(s.products))
-	for _, prod := range s.products {
-		products = append(products, prod)
+	products := make([]*pb.ProductInfo, 0, len(auctions))
+	for _, a := range auctions {
+		if snap := a.snapshot.Load(); snap != nil {
+			products = append(products, snap)
+		}
 	}
 
-	log.Printf("Sending catalog with %d products", len(products))
+	slog.DebugContext(ctx, "Sending catalog", "products", len(products))
 	return &pb.GetCatalogResponse{
 		Products: products,
 	}, nil
 }
 
-// GetProduct returns information about a specific product
+// GetProduct returns information about a specific product, or with as_of,
+// the product as it was at that moment
 func (s *AuctionServer) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	product := req.GetProduct()
-	if prod, exists := s.products[product]; exists {
+	if req.AsOf != nil {
+		t, err := asOf(req.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		info, found := s.history.Product(req.GetProduct(), t)
 		return &pb.GetProductResponse{
-			Found:   true,
-			Product: prod,
+			Found:   found,
+			Product: info,
 		}, nil
 	}
 
+	s.mu.RLock()
+	a, exists := s.products[req.GetProduct()]
+	s.mu.RUnlock()
+
+	if exists {
+		if snap := a.snapshot.Load(); snap != nil {
+			return &pb.GetProductResponse{
+				Found:   true,
+				Product: snap,
+			}, nil
+		}
+	}
+
 	return &pb.GetProductResponse{
 		Found: false,
 	}, nil
 }
 
+// asOf validates the moment of a point-in-time query
+func asOf(ts *timestamppb.Timestamp) (time.Time, error) {
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid as_of: %v", err)
+	}
+	return ts.AsTime(), nil
+}
+
 func main() {
+	cfg := defaultConfig()
+	printConfig, err := config.Load(cfg, "server", "AUCTION_SERVER_", os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if printConfig {
+		config.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		fatal("Invalid configuration", "error", err)
+	}
+	if printConfig {
+		return
+	}
+
+	// The settings are valid, so the level is known
+	var level slog.Level
+	level.UnmarshalText([]byte(cfg.Log.Level))
+	slog.SetDefault(logging.New(os.Stderr, level))
+
+	commission, err := ledger.ParseSchedule(cfg.Auction.Commission)
+	if err != nil {
+		fatal("Invalid commission schedule", "error", err)
+	}
+
+	var peers []cluster.Peer
+	if cfg.Cluster.Peers != "" {
+		if peers, err = cluster.ParsePeers(cfg.Cluster.Peers); err != nil {
+			fatal("Invalid cluster", "error", err)
+		}
+	}
+
+	rateLimits, err := ratelimit.ParseLimits(cfg.Auction.RateLimits)
+	if err != nil {
+		fatal("Invalid rate limits", "error", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "auction-server", cfg.Tracing.Exporter)
+	if err != nil {
+		fatal("Failed to set up tracing", "error", err)
+	}
+
+	eventLog, err := events.Open(cfg.Storage.path("events.log"))
+	if err != nil {
+		fatal("Failed to open event log", "error", err)
+	}
+
+	// The inbox is rebuilt from the event log, so it is kept in memory
+	inbox := notify.NewInbox()
+
+	// Roles are rebuilt from the event log too
+	roles := rbac.NewStore(rbac.DefaultPolicy)
+
+	tokens := make(staffTokens)
+	admins, err := parseStaffTokens(tokens, cfg.Staff.AdminTokens)
+	if err != nil {
+		fatal("Invalid admin tokens", "error", err)
+	}
+	if _, err := parseStaffTokens(tokens, cfg.Staff.StaffTokens); err != nil {
+		fatal("Invalid staff tokens", "error", err)
+	}
+	if len(tokens) == 0 {
+		slog.Warn("No staff tokens configured; the admin service will reject every call")
+	}
+
+	// Serve and replicate over TLS if there is a certificate; the files
+	// are read again whenever they change
+	serverCreds, clientCreds := insecure.NewCredentials(), insecure.NewCredentials()
+	var tlsFiles *certs.Reloader
+	if cfg.TLS.Cert != "" {
+		if tlsFiles, err = certs.NewReloader(cfg.TLS.Cert, cfg.TLS.Key, cfg.TLS.CA); err != nil {
+			fatal("Failed to load certificates", "error", err)
+		}
+		clientAuth, _ := certs.ParseClientAuth(cfg.TLS.ClientAuth)
+		serverCreds = credentials.NewTLS(tlsFiles.ServerConfig(clientAuth, "h2"))
+		clientCreds = credentials.NewTLS(tlsFiles.ClientConfig())
+		slog.Info("Serving over TLS", "cert", cfg.TLS.Cert, "client_auth", clientAuth.String())
+	}
+
 	// Create a TCP listener
-	lis, err := net.Listen("tcp", ":50051")
+	lis, err := net.Listen("tcp", cfg.Network.Addr)
 	if err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		fatal("Failed to start server", "error", err)
 	}
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	webhooks, err := webhook.NewDispatcher(webhook.Options{Path: cfg.Storage.path("webhooks.json")})
+	if err != nil {
+		fatal("Failed to load webhooks", "error", err)
+	}
 
-	// Register the auction service
-	pb.RegisterAuctionServiceServer(grpcServer, NewAuctionServer())
+	var mailer *email.Notifier
+	if cfg.Email.SMTP != "" {
+		renderer, err := email.NewRenderer(email.DefaultTemplates)
+		if err != nil {
+			fatal("Failed to parse email templates", "error", err)
+		}
+		transport := &email.SMTPTransport{Addr: cfg.Email.SMTP}
+		if cfg.Email.User != "" {
+			host, _, _ := net.SplitHostPort(cfg.Email.SMTP)
+			transport.Auth = smtp.PlainAuth("", cfg.Email.User, cfg.Email.Password, host)
+		}
+		mailer = email.NewNotifier(cfg.Email.From, renderer, transport)
+		slog.Info("Email notifications enabled", "smtp", cfg.Email.SMTP)
+	}
+
+	auctionServer := NewAuctionServer(eventLog, commission, inbox, webhooks, mailer, roles)
+	// Admin tokens hold the admin role whatever the restored log revoked
+	for _, name := range admins {
+		if _, err := roles.Grant(name, rbac.RoleAdmin); err != nil {
+			fatal("Failed to grant admin role", "user", name, "error", err)
+		}
+	}
+	adminServer := NewAdminServer(auctionServer, tokens)
+	authorizer := NewAuthorizer(roles, float32(cfg.Auction.VerifiedThreshold))
+	proxies := strings.Split(cfg.Network.TrustedProxies, ",")
+	if len(peers) > 0 {
+		// Followers forward calls on behalf of their clients
+		proxies = append(proxies, peerHosts(peers)...)
+	}
+	limiter := NewRateLimiter(rateLimits, proxies)
+	dedup := NewDeduplicator(idempotency.NewStore(cfg.Auction.IdempotencyTTL, maxIdempotencyKeys))
 
-	log.Println("Auction server started on port 50051...")
+	var node *cluster.Node
+	if len(peers) > 0 {
+		clusterCfg := cluster.Config{
+			NodeID: cfg.Cluster.Node,
+			Peers:  peers,
+			Dir:    filepath.Join(cfg.Storage.DataDir, "raft"),
+		}
+		if tlsFiles != nil {
+			// Nodes only replicate with peers holding a certificate issued
+			// to their ID
+			clusterCfg.ServerTLS = tlsFiles.ServerConfig(tls.RequireAndVerifyClientCert)
+			clusterCfg.ClientTLS = tlsFiles.ClientConfig()
+		}
+		node, err = auctionServer.joinCluster(clusterCfg)
+		if err != nil {
+			fatal("Failed to join cluster", "error", err)
+		}
+		slog.Info("Joined cluster", "node", cfg.Cluster.Node, "peers", len(peers))
+	}
+
+	registerMetrics(auctionServer, node)
+	var metricsServer *http.Server
+	if cfg.Network.MetricsAddr != "" {
+		metricsServer = serveMetrics(cfg.Network.MetricsAddr)
+	}
+
+	// Create gRPC server; every call is traced, timed and logged under its
+	// request ID, and clients with a certificate may only act for the user
+	// it names. In a cluster, followers hand writes to the leader, which
+	// runs the rest of the chain. Staff are authenticated before calls are
+	// rate limited and the role policy is evaluated. Only allowed
+	// calls reach the deduplicator, so rejections are never replayed.
+	requestLogger := NewRequestLogger(limiter)
+	certProxies := strings.Split(cfg.TLS.Proxies, ",")
+	for _, p := range peers {
+		certProxies = append(certProxies, p.ID)
+	}
+	identity := NewCertIdentity(certProxies)
+	unary := []grpc.UnaryServerInterceptor{MetricsUnaryInterceptor, requestLogger.UnaryInterceptor, identity.UnaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{MetricsStreamInterceptor, requestLogger.StreamInterceptor, identity.StreamInterceptor}
+	if node != nil {
+		forwarder := NewForwarder(node, limiter, clientCreds)
+		unary = append(unary, forwarder.UnaryInterceptor)
+		stream = append(stream, forwarder.StreamInterceptor)
+	}
+	unary = append(unary,
+		adminServer.UnaryInterceptor,
+		limiter.UnaryInterceptor,
+		authorizer.UnaryInterceptor,
+		dedup.UnaryInterceptor,
+	)
+	stream = append(stream, limiter.StreamInterceptor, authorizer.StreamInterceptor)
+	grpcServer := grpc.NewServer(
+		grpc.Creds(serverCreds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	// Register the auction and admin services
+	pb.RegisterAuctionServiceServer(grpcServer, auctionServer)
+	pb.RegisterAuctionAdminServiceServer(grpcServer, adminServer)
+
+	// Report health and let tools such as grpcurl discover the services
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+	healthDone := make(chan struct{})
+	go watchHealth(healthServer, node, healthDone)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Start serving
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			fatal("Failed to serve", "error", err)
+		}
+	}()
+	slog.Info("Auction server started", "addr", cfg.Network.Addr)
+
+	<-ctx.Done()
+	stop()
+	slog.Info("Shutting down", "timeout", cfg.Network.ShutdownTimeout.String())
+
+	// Turn away new calls, let those in flight finish, then save what is
+	// still buffered. The cluster is left before the event log is closed,
+	// as replication writes to it.
+	close(healthDone)
+	healthServer.Shutdown()
+	auctionServer.endStreams()
+	drain(grpcServer, cfg.Network.ShutdownTimeout)
+
+	if metricsServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Network.ShutdownTimeout)
+		metricsServer.Shutdown(shutdownCtx)
+		cancel()
+	}
+	webhooks.Stop()
+	if mailer != nil {
+		mailer.Close()
+	}
+	if node != nil {
+		if err := node.Close(); err != nil {
+			slog.Error("Failed to leave the cluster", "error", err)
+		}
+	}
+	if err := eventLog.Close(); err != nil {
+		slog.Error("Failed to close event log", "error", err)
+	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+	slog.Info("Auction server stopped")
+}
+
+// drain stops srv once the calls in flight finish, cutting them off after
+// timeout
+func drain(srv *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		slog.Warn("Calls still in flight at the shutdown timeout; cutting them off", "timeout", timeout.String())
+		srv.Stop()
+		<-stopped
 	}
 }
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"github.com/930r91na/Subasta-grpc/pkg/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMain(m *testing.M) {
+	// Every bid is logged; keep test and benchmark output readable
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+	os.Exit(m.Run())
+}
+
+// newTestServer returns an auction server keeping everything in memory
+func newTestServer(tb testing.TB) *AuctionServer {
+	tb.Helper()
+	eventLog, err := events.Open("")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	inbox := notify.NewInbox()
+	webhooks, err := webhook.NewDispatcher(webhook.Options{})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	roles := rbac.NewStore(rbac.DefaultPolicy)
+	s := NewAuctionServer(eventLog, ledger.DefaultSchedule, inbox, webhooks, nil, roles)
+	tb.Cleanup(func() {
+		s.endStreams()
+		webhooks.Stop()
+		eventLog.Close()
+	})
+	return s
+}
+
+// listProducts registers a seller and lists n products named p0, p1, ...
+// starting at price 1
+func listProducts(tb testing.TB, s *AuctionServer, n int) []string {
+	tb.Helper()
+	ctx := context.Background()
+	if _, err := s.RegisterUser(ctx, &pb.RegisterUserRequest{Name: "seller"}); err != nil {
+		tb.Fatal(err)
+	}
+	products := make([]string, n)
+	for i := range products {
+		products[i] = fmt.Sprintf("p%d", i)
+		resp, err := s.AddProduct(ctx, &pb.AddProductRequest{Seller: "seller", Product: products[i], InitialPrice: 1})
+		if err != nil || !resp.GetSuccess() {
+			tb.Fatalf("AddProduct(%s) = %v, %v", products[i], resp, err)
+		}
+	}
+	return products
+}
+
+// commitDelay stands in for a cluster's round trip to commit an event
+const commitDelay = time.Millisecond
+
+// delayedReplicator commits the events proposed to it after commitDelay,
+// those proposed meanwhile together, as a cluster does
+type delayedReplicator struct {
+	log       *events.Log
+	proposals chan proposal
+}
+
+// proposal is an event awaiting commit and where to report it
+type proposal struct {
+	e    events.Event
+	done chan error
+}
+
+func newDelayedReplicator(log *events.Log) *delayedReplicator {
+	r := &delayedReplicator{log: log, proposals: make(chan proposal, 1024)}
+	go r.commit()
+	return r
+}
+
+// Propose implements events.Replicator
+func (r *delayedReplicator) Propose(e events.Event) (func() error, error) {
+	p := proposal{e: e, done: make(chan error, 1)}
+	r.proposals <- p
+	return func() error { return <-p.done }, nil
+}
+
+// commit keeps the proposals in order, a batch per round trip
+func (r *delayedReplicator) commit() {
+	for p := range r.proposals {
+		batch := []proposal{p}
+		for len(r.proposals) > 0 {
+			batch = append(batch, <-r.proposals)
+		}
+		time.Sleep(commitDelay)
+		for _, p := range batch {
+			_, err := r.log.Receive(p.e)
+			p.done <- err
+		}
+	}
+}
+
+// BenchmarkPlaceBid places bids from many goroutines spread over 1, 10 and
+// 100 products, each bid waiting commitDelay to be committed as in a
+// cluster. Bids on one product wait for each other's commit, while bids on
+// different products hold different locks and are committed together, so
+// the time per bid falls several times over from 1 to 10 products and
+// again from 10 to 100.
+func BenchmarkPlaceBid(b *testing.B) {
+	for _, n := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("products=%d", n), func(b *testing.B) {
+			s := newTestServer(b)
+			products := listProducts(b, s, n)
+			s.events.ReplicateTo(newDelayedReplicator(s.events))
+			// Bids on each product rise, so most are accepted; those
+			// overtaken by a higher one are rejected as too low
+			prices := make([]atomic.Int64, n)
+			var next, rejected atomic.Int64
+			ctx := context.Background()
+
+			// Enough goroutines to keep every product busy
+			b.SetParallelism(2 * n)
+			b.ResetTimer()
+			b.RunParallel(func(bp *testing.PB) {
+				buyer := fmt.Sprintf("buyer%d", next.Add(1))
+				for bp.Next() {
+					p := int(next.Add(1)) % n
+					amount := float32(prices[p].Add(1) + 1)
+					resp, err := s.PlaceBid(ctx, &pb.PlaceBidRequest{Buyer: buyer, Product: products[p], Amount: amount})
+					if err != nil {
+						b.Fatal(err)
+					}
+					if !resp.GetSuccess() {
+						rejected.Add(1)
+					}
+				}
+			})
+			b.ReportMetric(float64(rejected.Load())/float64(b.N), "rejected/op")
+		})
+	}
+}
+
+// bidState is what a product shows at one version
+type bidState struct {
+	price  float32
+	bidder string
+}
+
+// TestConcurrentBidsAndReads bids on products while reading them, and
+// checks that every snapshot read shows the price and bidder of its
+// version, and that a reader never sees a product's version go back. Run
+// it with -race.
+func TestConcurrentBidsAndReads(t *testing.T) {
+	const (
+		bidders = 8
+		readers = 4
+		bids    = 200
+	)
+	s := newTestServer(t)
+	products := listProducts(t, s, 4)
+	ctx := context.Background()
+
+	// accepted holds the state each accepted bid left its product in
+	var mu sync.Mutex
+	accepted := make(map[string]map[uint64]bidState)
+	for _, p := range products {
+		accepted[p] = map[uint64]bidState{1: {price: 1}}
+	}
+
+	var bidding sync.WaitGroup
+	for i := 0; i < bidders; i++ {
+		buyer := fmt.Sprintf("buyer%d", i)
+		bidding.Add(1)
+		go func() {
+			defer bidding.Done()
+			for j := 0; j < bids; j++ {
+				product := products[(i+j)%len(products)]
+				got, err := s.GetProduct(ctx, &pb.GetProductRequest{Product: product})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				info := got.GetProduct()
+				amount := info.GetCurrentPrice() + 1
+				version := info.GetVersion()
+				resp, err := s.PlaceBid(ctx, &pb.PlaceBidRequest{
+					Buyer:           buyer,
+					Product:         product,
+					Amount:          amount,
+					ExpectedVersion: &version,
+				})
+				if status.Code(err) == codes.Aborted {
+					// Another bid got there first
+					continue
+				}
+				if err != nil || !resp.GetSuccess() {
+					t.Errorf("PlaceBid on %s at version %d = %v, %v", product, version, resp, err)
+					return
+				}
+				mu.Lock()
+				accepted[product][resp.GetVersion()] = bidState{price: amount, bidder: buyer}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	seen := make(chan []*pb.ProductInfo, readers)
+	for i := 0; i < readers; i++ {
+		go func() {
+			var snapshots []*pb.ProductInfo
+			defer func() { seen <- snapshots }()
+			last := make(map[string]uint64)
+			read := func(info *pb.ProductInfo) {
+				if v := info.GetVersion(); v < last[info.GetProduct()] {
+					t.Errorf("%s went from version %d back to %d", info.GetProduct(), last[info.GetProduct()], v)
+				} else {
+					last[info.GetProduct()] = v
+				}
+				snapshots = append(snapshots, info)
+			}
+			for n := 0; ; n++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if n%2 == 0 {
+					catalog, err := s.GetCatalog(ctx, &pb.GetCatalogRequest{})
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					for _, info := range catalog.GetProducts() {
+						read(info)
+					}
+				} else {
+					got, err := s.GetProduct(ctx, &pb.GetProductRequest{Product: products[n%len(products)]})
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					read(got.GetProduct())
+				}
+			}
+		}()
+	}
+
+	bidding.Wait()
+	close(done)
+	checked := 0
+	for i := 0; i < readers; i++ {
+		for _, info := range <-seen {
+			want, ok := accepted[info.GetProduct()][info.GetVersion()]
+			if !ok {
+				t.Errorf("%s at version %d was never bid to", info.GetProduct(), info.GetVersion())
+				continue
+			}
+			if got := (bidState{price: info.GetCurrentPrice(), bidder: info.GetHighestBidder()}); got != want {
+				t.Errorf("%s at version %d shows %+v, want %+v", info.GetProduct(), info.GetVersion(), got, want)
+			}
+			checked++
+		}
+	}
+	if checked == 0 {
+		t.Error("no snapshots read")
+	}
+
+	for _, product := range products {
+		got, _ := s.GetProduct(ctx, &pb.GetProductRequest{Product: product})
+		if versions := uint64(len(accepted[product])); got.GetProduct().GetVersion() != versions {
+			t.Errorf("%s is at version %d after %d accepted bids", product, got.GetProduct().GetVersion(), versions-1)
+		}
+	}
+}
+
+// TestStaleBidsAndPastReads checks that a bid against an outdated version
+// or price is aborted, and that a product reads as it was after any event
+func TestStaleBidsAndPastReads(t *testing.T) {
+	s := newTestServer(t)
+	product := listProducts(t, s, 1)[0]
+	ctx := context.Background()
+
+	var (
+		seqs     []uint64
+		versions []uint64
+	)
+	for i, buyer := range []string{"Alice", "Bob", "Alice"} {
+		resp, err := s.PlaceBid(ctx, &pb.PlaceBidRequest{Buyer: buyer, Product: product, Amount: float32(10 * (i + 1))})
+		if err != nil || !resp.GetSuccess() {
+			t.Fatalf("PlaceBid = %v, %v", resp, err)
+		}
+		recorded := s.events.Events()
+		seqs = append(seqs, recorded[len(recorded)-1].Seq)
+		versions = append(versions, resp.GetVersion())
+	}
+
+	stale, current := versions[1], versions[2]
+	staleBids := map[string]*pb.PlaceBidRequest{
+		"version": {Buyer: "Bob", Product: product, Amount: 100, ExpectedVersion: &stale},
+		"price":   {Buyer: "Bob", Product: product, Amount: 100, ExpectedCurrentPrice: proto.Float32(20)},
+	}
+	for name, req := range staleBids {
+		if _, err := s.PlaceBid(ctx, req); status.Code(err) != codes.Aborted {
+			t.Errorf("bid with a stale %s = %v, want Aborted", name, err)
+		}
+	}
+	if resp, err := s.PlaceBid(ctx, &pb.PlaceBidRequest{Buyer: "Bob", Product: product, Amount: 100, ExpectedVersion: &current}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("bid at the current version = %v, %v", resp, err)
+	}
+
+	// Each bid's event shows the product as that bid left it
+	recorded := s.events.Events()
+	for i, seq := range seqs {
+		asOf := timestamppb.New(recorded[seq-1].Time)
+		resp, err := s.GetProduct(ctx, &pb.GetProductRequest{Product: product, AsOf: asOf})
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := resp.GetProduct()
+		if info.GetVersion() != versions[i] || info.GetCurrentPrice() != float32(10*(i+1)) {
+			t.Errorf("%s at seq %d = version %d at %.2f, want version %d at %.2f",
+				product, seq, info.GetVersion(), info.GetCurrentPrice(), versions[i], float32(10*(i+1)))
+		}
+	}
+	before := timestamppb.New(recorded[0].Time.Add(-time.Second))
+	if resp, err := s.GetProduct(ctx, &pb.GetProductRequest{Product: product, AsOf: before}); err != nil || resp.GetFound() {
+		t.Errorf("%s before it was listed = %v, %v", product, resp, err)
+	}
+	invalid := &timestamppb.Timestamp{Nanos: -1}
+	if _, err := s.GetProduct(ctx, &pb.GetProductRequest{Product: product, AsOf: invalid}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid as_of = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/cluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics exported on the -metrics-addr listener
+var (
+	rpcSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "auction",
+		Name:      "grpc_server_handling_seconds",
+		Help:      "Time taken to handle gRPC calls, by method and status code.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	}, []string{"method", "code"})
+
+	bidsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "auction",
+		Name:      "bids_total",
+		Help:      "Bids placed, by product, result (accepted or rejected) and the reason a bid was rejected.",
+	}, []string{"product", "result", "reason"})
+
+	lockWaitSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "auction",
+		Name:      "product_lock_wait_seconds",
+		Help:      "Time calls waited for a product's lock.",
+		Buckets:   prometheus.ExponentialBuckets(0.000001, 4, 12),
+	})
+
+	eventSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "auction",
+		Name:      "event_append_seconds",
+		Help:      "Time taken to record events, including replication in a cluster, by event type.",
+		Buckets:   prometheus.ExponentialBuckets(0.00005, 2, 16),
+	}, []string{"type"})
+)
+
+// Reasons bids are rejected
+const (
+	bidTooLow         = "too_low"
+	bidClosed         = "closed"
+	bidFrozen         = "frozen"
+	bidSuspended      = "suspended"
+	bidStale          = "stale"
+	bidUnknownProduct = "unknown_product"
+)
+
+// bidAccepted counts an accepted bid
+func bidAccepted(product string) {
+	bidsTotal.WithLabelValues(product, "accepted", "").Inc()
+}
+
+// bidRejected counts a rejected bid. Bids on products that do not exist are
+// counted without the product, so clients cannot mint new series.
+func bidRejected(product, reason string) {
+	if reason == bidUnknownProduct {
+		product = ""
+	}
+	bidsTotal.WithLabelValues(product, "rejected", reason).Inc()
+}
+
+// forgetProductMetrics drops the series of a removed product
+func forgetProductMetrics(product string) {
+	bidsTotal.DeletePartialMatch(prometheus.Labels{"product": product})
+}
+
+// registerMetrics registers the server's metrics, including gauges read
+// from its state when scraped. node is nil for a standalone server.
+func registerMetrics(s *AuctionServer, node *cluster.Node) {
+	prometheus.MustRegister(rpcSeconds, bidsTotal, lockWaitSeconds, eventSeconds)
+
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "auction",
+		Name:      "active_listings",
+		Help:      "Products listed whose auction has not closed.",
+	}, func() float64 {
+		active := 0
+		for _, a := range s.auctions() {
+			if snap := a.snapshot.Load(); snap != nil && !snap.Closed {
+				active++
+			}
+		}
+		return float64(active)
+	}))
+
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "auction",
+		Name:      "registered_users",
+		Help:      "Users registered with the auction.",
+	}, func() float64 {
+		s.usersMu.RLock()
+		defer s.usersMu.RUnlock()
+		return float64(len(s.users))
+	}))
+
+	if node != nil {
+		prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "auction",
+			Name:      "cluster_leader",
+			Help:      "1 if this node leads its cluster and accepts writes, 0 otherwise.",
+		}, func() float64 {
+			if node.IsLeader() {
+				return 1
+			}
+			return 0
+		}))
+	}
+}
+
+// serveMetrics serves /metrics on addr in the background
+func serveMetrics(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			fatal("Failed to serve metrics", "error", err)
+		}
+	}()
+	slog.Info("Serving metrics", "addr", addr)
+	return srv
+}
+
+// MetricsUnaryInterceptor times unary calls
+func MetricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	rpcSeconds.WithLabelValues(path.Base(info.FullMethod), status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// MetricsStreamInterceptor times streams, from opening to the end
+func MetricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	rpcSeconds.WithLabelValues(path.Base(info.FullMethod), status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return err
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/email"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// notifications is a projection raising the notifications events call for
+// into the inbox. Every node of a cluster raises the same ones in the same
+// order, and a restarted server raises them again from its event log, so
+// the inbox needs no file of its own.
+type notifications struct {
+	inbox  *notify.Inbox
+	mailer *email.Notifier
+	// mailing reports whether to email the notifications raised now; it is
+	// nil while the event log is replayed
+	mailing  func() bool
+	users    map[string]*pb.User
+	products map[string]*events.Product
+}
+
+func newNotifications(inbox *notify.Inbox, mailer *email.Notifier) *notifications {
+	return &notifications{
+		inbox:    inbox,
+		mailer:   mailer,
+		users:    make(map[string]*pb.User),
+		products: make(map[string]*events.Product),
+	}
+}
+
+// Apply implements events.Projection
+func (p *notifications) Apply(e events.Event) {
+	switch d := e.Data.(type) {
+	case *events.UserRegistered, *events.ProfileUpdated, *events.UserSuspended:
+		events.ApplyUser(p.users, e)
+
+	case *events.ProductListed:
+		p.products[d.Product] = events.NewProduct(d)
+
+	case *events.BidPlaced:
+		product, ok := p.products[d.Product]
+		if !ok {
+			return
+		}
+		previous := product.Info.HighestBidder
+		product.Apply(e)
+		if previous != "" && previous != d.Buyer {
+			p.notify(e, previous, notify.KindOutbid,
+				fmt.Sprintf("You were outbid on %s: %s offered %.2f", d.Product, d.Buyer, d.Amount), d.Amount)
+		}
+		p.notifyWatchers(e, product, notify.KindWatchedBid,
+			fmt.Sprintf("%s offered %.2f for %s", d.Buyer, d.Amount, d.Product), d.Amount, d.Buyer, previous)
+
+	case *events.AuctionClosed:
+		product, ok := p.products[d.Product]
+		if !ok {
+			return
+		}
+		product.Apply(e)
+		p.notifyClosed(e, product)
+
+	case *events.ProductRemoved:
+		product, ok := p.products[d.Product]
+		if !ok {
+			return
+		}
+		delete(p.products, d.Product)
+		message := fmt.Sprintf("The listing %s was removed by a moderator", d.Product)
+		told := []string{product.Info.Seller}
+		p.notify(e, product.Info.Seller, notify.KindListingEnded, message, 0)
+		for _, buyer := range bidders(product) {
+			if !contains(told, buyer) {
+				p.notify(e, buyer, notify.KindListingEnded, message, 0)
+				told = append(told, buyer)
+			}
+		}
+		p.notifyWatchers(e, product, notify.KindListingEnded, message, 0, told...)
+
+	case *events.NotificationRead:
+		// Only notifications that exist are marked read
+		p.inbox.Ack(d.User, d.ID)
+
+	default:
+		if product, ok := p.products[e.Product()]; ok {
+			product.Apply(e)
+		}
+	}
+}
+
+// notify stores a notification raised by an event in a user's inbox and
+// emails it if the user asked for it
+func (p *notifications) notify(e events.Event, user string, kind notify.Kind, message string, amount float32) {
+	n := p.inbox.Push(user, kind, e.Product(), message, amount, e.Time)
+	if p.mailer == nil || p.mailing == nil || !p.mailing() {
+		return
+	}
+	if to, ok := wantsEmail(p.users[user], kind); ok {
+		p.mailer.Notify(to, string(kind), n)
+	}
+}
+
+// notifyClosed tells the seller and every bidder how an auction ended
+func (p *notifications) notifyClosed(e events.Event, product *events.Product) {
+	productInfo := product.Info
+	name := productInfo.Product
+	winner := productInfo.HighestBidder
+	price := productInfo.CurrentPrice
+
+	if winner == "" {
+		p.notify(e, productInfo.Seller, notify.KindListingEnded,
+			fmt.Sprintf("Your listing %s ended without bids", name), 0)
+		p.notifyWatchers(e, product, notify.KindListingEnded,
+			fmt.Sprintf("The auction for %s ended without bids", name), 0, productInfo.Seller)
+		return
+	}
+
+	p.notify(e, productInfo.Seller, notify.KindItemSold,
+		fmt.Sprintf("%s sold to %s for %.2f", name, winner, price), price)
+	p.notify(e, winner, notify.KindWon,
+		fmt.Sprintf("You won %s for %.2f", name, price), price)
+
+	told := []string{productInfo.Seller, winner}
+	for _, buyer := range bidders(product) {
+		if buyer != winner {
+			p.notify(e, buyer, notify.KindLost,
+				fmt.Sprintf("%s was sold to another bidder for %.2f", name, price), price)
+			told = append(told, buyer)
+		}
+	}
+	p.notifyWatchers(e, product, notify.KindListingEnded,
+		fmt.Sprintf("The auction for %s ended: sold for %.2f", name, price), price, told...)
+}
+
+// notifyWatchers notifies everyone watching a product except the users in
+// skip, who have already been told
+func (p *notifications) notifyWatchers(e events.Event, product *events.Product, kind notify.Kind, message string, amount float32, skip ...string) {
+	watchers := make([]string, 0, len(product.Watchers))
+	for user := range product.Watchers {
+		watchers = append(watchers, user)
+	}
+	// Every node numbers the notifications alike
+	sort.Strings(watchers)
+	for _, user := range watchers {
+		if !contains(skip, user) {
+			p.notify(e, user, kind, message, amount)
+		}
+	}
+}
+
+// bidders returns the users with a bid on a product, sorted
+func bidders(product *events.Product) []string {
+	buyers := make([]string, 0, len(product.Bids))
+	for buyer := range product.Bids {
+		buyers = append(buyers, buyer)
+	}
+	sort.Strings(buyers)
+	return buyers
+}
+
+// ListNotifications returns the notifications in a user's inbox
+func (s *AuctionServer) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	list, unread := s.inbox.List(req.GetUser(), req.GetUnreadOnly())
+
+	notifications := make([]*pb.Notification, 0, len(list))
+	for _, n := range list {
+		notifications = append(notifications, toProtoNotification(n))
+	}
+
+	return &pb.ListNotificationsResponse{
+		Notifications: notifications,
+		Unread:        int32(unread),
+	}, nil
+}
+
+// AckNotification marks one or all of a user's notifications as read
+func (s *AuctionServer) AckNotification(ctx context.Context, req *pb.AckNotificationRequest) (*pb.AckNotificationResponse, error) {
+	user, id := req.GetUser(), req.GetId()
+	if id != 0 && !hasNotification(s.inbox, user, id) {
+		return &pb.AckNotificationResponse{
+			Success: false,
+			Message: fmt.Sprintf("Notification %d not found", id),
+		}, nil
+	}
+	// The inbox is a projection of the event log, so every node marks it
+	if _, err := s.record(ctx, &events.NotificationRead{User: user, ID: id}); err != nil {
+		return nil, err
+	}
+
+	return &pb.AckNotificationResponse{
+		Success: true,
+		Message: "Notifications marked as read",
+	}, nil
+}
+
+// hasNotification reports whether a user's inbox holds a notification
+func hasNotification(inbox *notify.Inbox, user string, id int64) bool {
+	list, _ := inbox.List(user, false)
+	for _, n := range list {
+		if n.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SubscribeNotifications streams a user's new notifications until the
+// client goes away or the server shuts down
+func (s *AuctionServer) SubscribeNotifications(req *pb.SubscribeNotificationsRequest, stream pb.AuctionService_SubscribeNotificationsServer) error {
+	user := req.GetUser()
+	ch, cancel := s.inbox.Subscribe(user)
+	defer cancel()
+
+	// The headers tell the client it is subscribed, before any notification
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	slog.InfoContext(stream.Context(), "User subscribed to notifications", "user", user)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server shutting down, subscribe again")
+		case n := <-ch:
+			if err := stream.Send(toProtoNotification(n)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toProtoNotification(n notify.Notification) *pb.Notification {
+	return &pb.Notification{
+		Id:      n.ID,
+		User:    n.User,
+		Kind:    string(n.Kind),
+		Product: n.Product,
+		Message: n.Message,
+		Amount:  n.Amount,
+		Time:    timestamppb.New(n.Time),
+		Read:    n.Read,
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"github.com/930r91na/Subasta-grpc/pkg/webhook"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestNotificationsRebuilt checks that a server started on an existing
+// event log raises the same notifications, read the same way, as the
+// server that recorded it
+func TestNotificationsRebuilt(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	listProducts(t, s, 1)
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		if _, err := s.RegisterUser(ctx, &pb.RegisterUserRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.AddToWatchlist(ctx, &pb.AddToWatchlistRequest{User: "Carol", Product: "p0"}); err != nil {
+		t.Fatal(err)
+	}
+	for i, buyer := range []string{"Alice", "Bob", "Alice"} {
+		resp, err := s.PlaceBid(ctx, &pb.PlaceBidRequest{Buyer: buyer, Product: "p0", Amount: float32(10 * (i + 1))})
+		if err != nil || !resp.GetSuccess() {
+			t.Fatalf("PlaceBid(%s) = %v, %v", buyer, resp, err)
+		}
+	}
+	if resp, err := s.CloseAuction(ctx, &pb.CloseAuctionRequest{Seller: "seller", Product: "p0"}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("CloseAuction = %v, %v", resp, err)
+	}
+
+	bob, err := s.ListNotifications(ctx, &pb.ListNotificationsRequest{User: "Bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Outbid by Alice, then lost to her
+	if len(bob.GetNotifications()) != 2 {
+		t.Fatalf("Bob's notifications = %v", bob.GetNotifications())
+	}
+	id := bob.GetNotifications()[0].GetId()
+	if resp, err := s.AckNotification(ctx, &pb.AckNotificationRequest{User: "Bob", Id: id}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("AckNotification = %v, %v", resp, err)
+	}
+	if resp, _ := s.AckNotification(ctx, &pb.AckNotificationRequest{User: "Bob", Id: 999}); resp.GetSuccess() {
+		t.Error("unknown notification acknowledged")
+	}
+
+	inbox := notify.NewInbox()
+	webhooks, _ := webhook.NewDispatcher(webhook.Options{})
+	defer webhooks.Stop()
+	roles := rbac.NewStore(rbac.DefaultPolicy)
+	restarted := NewAuctionServer(s.events, ledger.DefaultSchedule, inbox, webhooks, nil, roles)
+
+	for _, user := range []string{"seller", "Alice", "Bob", "Carol"} {
+		want, _ := s.ListNotifications(ctx, &pb.ListNotificationsRequest{User: user})
+		got, _ := restarted.ListNotifications(ctx, &pb.ListNotificationsRequest{User: user})
+		if len(want.GetNotifications()) == 0 {
+			t.Errorf("%s has no notifications", user)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("%s's notifications after restart:\n%v\nwant\n%v", user, got, want)
+		}
+	}
+}
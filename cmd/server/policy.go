@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// auctionServicePrefix prefixes the full method names of the auction service
+const auctionServicePrefix = "/auction.AuctionService/"
+
+// actingUsers names the user acting in each AuctionService request. Unless
+// the call authenticates its user, this is only who the request claims to
+// act for.
+var actingUsers = map[string]func(req interface{}) string{
+	"RegisterUser":        func(req interface{}) string { return req.(*pb.RegisterUserRequest).GetName() },
+	"GetProfile":          func(req interface{}) string { return req.(*pb.GetProfileRequest).GetName() },
+	"UpdateProfile":       func(req interface{}) string { return req.(*pb.UpdateProfileRequest).GetName() },
+	"AddProduct":          func(req interface{}) string { return req.(*pb.AddProductRequest).GetSeller() },
+	"PlaceBid":            func(req interface{}) string { return req.(*pb.PlaceBidRequest).GetBuyer() },
+	"CloseAuction":        func(req interface{}) string { return req.(*pb.CloseAuctionRequest).GetSeller() },
+	"AddToWatchlist":      func(req interface{}) string { return req.(*pb.AddToWatchlistRequest).GetUser() },
+	"RemoveFromWatchlist": func(req interface{}) string { return req.(*pb.RemoveFromWatchlistRequest).GetUser() },
+	"ListWatchlist":       func(req interface{}) string { return req.(*pb.ListWatchlistRequest).GetUser() },
+}
+
+// owners names the user owning the record each AuctionService request
+// reads or changes, for requests that staff may also make for others. ""
+// means no user owns it.
+var owners = map[string]func(req interface{}) string{
+	"ListNotifications":      func(req interface{}) string { return req.(*pb.ListNotificationsRequest).GetUser() },
+	"AckNotification":        func(req interface{}) string { return req.(*pb.AckNotificationRequest).GetUser() },
+	"SubscribeNotifications": func(req interface{}) string { return req.(*pb.SubscribeNotificationsRequest).GetUser() },
+	"GetStatement": func(req interface{}) string {
+		return ledger.ParseAccount(req.(*pb.GetStatementRequest).GetAccount()).User()
+	},
+}
+
+// authenticatedUser returns who the credentials of a call prove is acting:
+// the staff member whose token the admin service accepted, or the user of
+// the client's certificate. It is "" when the call only names a user.
+func authenticatedUser(ctx context.Context, method string) string {
+	if strings.HasPrefix(method, adminServicePrefix) {
+		return adminFromContext(ctx)
+	}
+	return certUserFromContext(ctx)
+}
+
+// subjectOf returns who is acting in a call: its authenticated user, or
+// else the user the request names as acting or as owning the record
+func subjectOf(ctx context.Context, method string, req interface{}) string {
+	if user := authenticatedUser(ctx, method); user != "" {
+		return user
+	}
+	name, ok := strings.CutPrefix(method, auctionServicePrefix)
+	if !ok {
+		return ""
+	}
+	if user, ok := actingUsers[name]; ok {
+		return user(req)
+	}
+	if owner, ok := owners[name]; ok {
+		return owner(req)
+	}
+	return ""
+}
+
+// claimedRoles are the roles honoured for a user a call names without
+// authenticating: those every registered user holds. Naming a moderator
+// or an admin thus gains nothing.
+var claimedRoles = rbac.DefaultRoles
+
+// rule lists what the subject of a call needs to be allowed to make it
+type rule func(subject string, req interface{}) []rbac.Permission
+
+// Authorizer evaluates the role policy for every gRPC call
+type Authorizer struct {
+	roles *rbac.Store
+	rules map[string]rule
+}
+
+// NewAuthorizer creates the authorizer for the auction's services. Listings
+// starting at or above highValue need the list.high_value permission.
+func NewAuthorizer(roles *rbac.Store, highValue float32) *Authorizer {
+	need := func(perms ...rbac.Permission) rule {
+		return func(string, interface{}) []rbac.Permission { return perms }
+	}
+
+	rules := map[string]rule{
+		auctionServicePrefix + "PlaceBid": need(rbac.PermBid),
+		auctionServicePrefix + "AddProduct": func(_ string, req interface{}) []rbac.Permission {
+			if req.(*pb.AddProductRequest).GetInitialPrice() >= highValue {
+				return []rbac.Permission{rbac.PermList, rbac.PermListHighValue}
+			}
+			return []rbac.Permission{rbac.PermList}
+		},
+		auctionServicePrefix + "CloseAuction":        need(rbac.PermList),
+		auctionServicePrefix + "AddToWatchlist":      need(rbac.PermWatch),
+		auctionServicePrefix + "RemoveFromWatchlist": need(rbac.PermWatch),
+		auctionServicePrefix + "UpdateProfile":       need(rbac.PermProfile),
+	}
+
+	for method, perms := range map[string][]rbac.Permission{
+		"RegisterWebhook":   {rbac.PermManageWebhooks},
+		"ListWebhooks":      {rbac.PermManageWebhooks},
+		"DeleteWebhook":     {rbac.PermManageWebhooks},
+		"ListDeadLetters":   {rbac.PermManageWebhooks},
+		"ReplayWebhook":     {rbac.PermManageWebhooks},
+		"SuspendUser":       {rbac.PermModerate},
+		"RemoveProduct":     {rbac.PermModerate},
+		"FreezeProduct":     {rbac.PermModerate},
+		"VoidBid":           {rbac.PermModerate},
+		"ForceCloseAuction": {rbac.PermModerate},
+		"GetServerState":    {rbac.PermInspect},
+		"ListAuditLog":      {rbac.PermInspect},
+		"ListRoles":         {rbac.PermInspect},
+		"GrantRole":         {rbac.PermManageRoles},
+		"RevokeRole":        {rbac.PermManageRoles},
+	} {
+		rules[adminServicePrefix+method] = need(perms...)
+	}
+
+	// Users may read and change their own records; anyone else needs to be
+	// allowed to inspect
+	for method, owner := range owners {
+		rules[auctionServicePrefix+method] = func(subject string, req interface{}) []rbac.Permission {
+			if o := owner(req); o == "" || o != subject {
+				return []rbac.Permission{rbac.PermInspect}
+			}
+			return nil
+		}
+	}
+
+	return &Authorizer{roles: roles, rules: rules}
+}
+
+// authorize checks a call against the policy. Methods without a rule, such
+// as reading the catalog, are open to everyone. A user the call only names
+// is held to the roles every user has.
+func (z *Authorizer) authorize(ctx context.Context, method string, req interface{}) error {
+	permissions, ok := z.rules[method]
+	if !ok {
+		return nil
+	}
+
+	subject := subjectOf(ctx, method, req)
+	if subject == "" {
+		return status.Error(codes.Unauthenticated, "no acting user in request")
+	}
+	roles := z.roles.Roles(subject)
+	authenticated := authenticatedUser(ctx, method) != ""
+	if !authenticated {
+		roles = slices.DeleteFunc(roles, func(r rbac.Role) bool { return !slices.Contains(claimedRoles, r) })
+	}
+
+	for _, perm := range permissions(subject, req) {
+		if z.roles.Policy().Allows(roles, perm) {
+			continue
+		}
+		if !authenticated && z.roles.Can(subject, perm) {
+			return status.Error(codes.Unauthenticated,
+				fmt.Sprintf("%s must authenticate to use the %s permission", subject, perm))
+		}
+		return status.Error(codes.PermissionDenied,
+			fmt.Sprintf("%s lacks the %s permission", subject, perm))
+	}
+	return nil
+}
+
+// UnaryInterceptor rejects calls the acting user's roles do not allow
+func (z *Authorizer) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := z.authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor rejects streams whose requests the acting user's roles
+// do not allow, as they are received
+func (z *Authorizer) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if _, ok := z.rules[info.FullMethod]; !ok {
+		return handler(srv, ss)
+	}
+	return handler(srv, &authorizedStream{ServerStream: ss, authorizer: z, method: info.FullMethod})
+}
+
+// authorizedStream checks every request received on a stream
+type authorizedStream struct {
+	grpc.ServerStream
+	authorizer *Authorizer
+	method     string
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.authorizer.authorize(s.Context(), s.method, m)
+}
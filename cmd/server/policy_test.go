@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+	"github.com/930r91na/Subasta-grpc/pkg/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorize(t *testing.T) {
+	roles := rbac.NewStore(rbac.DefaultPolicy)
+	for name, held := range map[string][]rbac.Role{
+		"Alice": rbac.DefaultRoles,
+		"Bob":   append([]rbac.Role{rbac.RoleModerator}, rbac.DefaultRoles...),
+		"Vera":  append([]rbac.Role{rbac.RoleVerifiedSeller}, rbac.DefaultRoles...),
+		"mod":   {rbac.RoleModerator},
+		"root":  {rbac.RoleAdmin},
+	} {
+		if _, err := roles.Grant(name, held...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	z := NewAuthorizer(roles, 1000)
+
+	// cert is a call whose client certificate identifies user; staff is a
+	// call to the admin service whose token belongs to name
+	cert := func(user string) context.Context {
+		return context.WithValue(context.Background(), certUserContextKey{}, user)
+	}
+	staff := func(name string) context.Context {
+		return context.WithValue(context.Background(), adminContextKey{}, name)
+	}
+	named := context.Background()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    interface{}
+		want   codes.Code
+	}{
+		// Owners read their own records; others need to inspect
+		{"owner", named, "ListNotifications", &pb.ListNotificationsRequest{User: "Alice"}, codes.OK},
+		{"certified owner", cert("Alice"), "GetStatement", &pb.GetStatementRequest{Account: "Alice"}, codes.OK},
+		{"non-owner", cert("Vera"), "ListNotifications", &pb.ListNotificationsRequest{User: "Alice"}, codes.PermissionDenied},
+		{"non-owner inspecting", cert("Bob"), "AckNotification", &pb.AckNotificationRequest{User: "Alice"}, codes.OK},
+		{"house account", cert("Alice"), "GetStatement", &pb.GetStatementRequest{Account: string(ledger.HouseCommission)}, codes.PermissionDenied},
+		{"house account unnamed", named, "GetStatement", &pb.GetStatementRequest{Account: string(ledger.HouseCommission)}, codes.Unauthenticated},
+
+		// Listing at the threshold needs a verified seller
+		{"below threshold", named, "AddProduct", &pb.AddProductRequest{Seller: "Alice", InitialPrice: 999}, codes.OK},
+		{"at threshold", cert("Alice"), "AddProduct", &pb.AddProductRequest{Seller: "Alice", InitialPrice: 1000}, codes.PermissionDenied},
+		{"verified at threshold", cert("Vera"), "AddProduct", &pb.AddProductRequest{Seller: "Vera", InitialPrice: 1000}, codes.OK},
+
+		// Naming a user grants only the roles every user has
+		{"verified claimed", named, "AddProduct", &pb.AddProductRequest{Seller: "Vera", InitialPrice: 1000}, codes.Unauthenticated},
+		{"admin claimed", named, "AddProduct", &pb.AddProductRequest{Seller: "root", InitialPrice: 1000}, codes.Unauthenticated},
+		{"admin certified", cert("root"), "AddProduct", &pb.AddProductRequest{Seller: "root", InitialPrice: 1000}, codes.OK},
+		{"moderator claimed", named, "PlaceBid", &pb.PlaceBidRequest{Buyer: "mod"}, codes.PermissionDenied},
+		{"unknown user claimed", named, "PlaceBid", &pb.PlaceBidRequest{Buyer: "Mallory"}, codes.PermissionDenied},
+
+		// The admin service goes by the staff token alone
+		{"no token", cert("root"), "GetServerState", &pb.GetServerStateRequest{}, codes.Unauthenticated},
+		{"staff without roles", staff("clerk"), "GetServerState", &pb.GetServerStateRequest{}, codes.PermissionDenied},
+		{"moderator moderating", staff("mod"), "RemoveProduct", &pb.RemoveProductRequest{}, codes.OK},
+		{"moderator granting", staff("mod"), "GrantRole", &pb.GrantRoleRequest{}, codes.PermissionDenied},
+		{"moderator managing webhooks", staff("mod"), "ListWebhooks", &pb.ListWebhooksRequest{}, codes.PermissionDenied},
+		{"admin granting", staff("root"), "GrantRole", &pb.GrantRoleRequest{}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := auctionServicePrefix
+			if _, ok := z.rules[adminServicePrefix+tt.method]; ok {
+				prefix = adminServicePrefix
+			}
+			err := z.authorize(tt.ctx, prefix+tt.method, tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("authorize(%s) = %v, want %v", tt.method, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRolesRestored(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.WithValue(context.Background(), adminContextKey{}, "root")
+	admin := &AdminServer{auction: s}
+
+	if _, err := s.RegisterUser(ctx, &pb.RegisterUserRequest{Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, role := range []rbac.Role{rbac.RoleVerifiedSeller, rbac.RoleModerator} {
+		if _, err := admin.GrantRole(ctx, &pb.GrantRoleRequest{User: "Alice", Role: string(role)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := admin.RevokeRole(ctx, &pb.RevokeRoleRequest{User: "Alice", Role: string(rbac.RoleModerator)}); err != nil {
+		t.Fatal(err)
+	}
+
+	webhooks, _ := webhook.NewDispatcher(webhook.Options{})
+	defer webhooks.Stop()
+	roles := rbac.NewStore(rbac.DefaultPolicy)
+	NewAuctionServer(s.events, ledger.DefaultSchedule, notify.NewInbox(), webhooks, nil, roles)
+	want := []rbac.Role{rbac.RoleBuyer, rbac.RoleSeller, rbac.RoleVerifiedSeller}
+	if got := roles.Roles("Alice"); !slices.Equal(got, want) {
+		t.Errorf("restored roles = %v, want %v", got, want)
+	}
+}
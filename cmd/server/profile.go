@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/email"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+	"google.golang.org/protobuf/proto"
+)
+
+// notificationKinds are the notification kinds a user can choose to receive
+// by email
+var notificationKinds = map[string]bool{
+	string(notify.KindOutbid):       true,
+	string(notify.KindWon):          true,
+	string(notify.KindLost):         true,
+	string(notify.KindItemSold):     true,
+	string(notify.KindListingEnded): true,
+	string(notify.KindWatchedBid):   true,
+}
+
+// GetProfile returns a user's profile
+func (s *AuctionServer) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
+	s.usersMu.RLock()
+	defer s.usersMu.RUnlock()
+
+	user, exists := s.users[req.GetName()]
+	if !exists {
+		return &pb.GetProfileResponse{Found: false}, nil
+	}
+
+	return &pb.GetProfileResponse{
+		Found: true,
+		User:  s.userView(user),
+	}, nil
+}
+
+// UpdateProfile sets a user's email address and email preferences
+func (s *AuctionServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
+	s.usersMu.Lock()
+	defer s.usersMu.Unlock()
+
+	name := req.GetName()
+	user, exists := s.users[name]
+	if !exists {
+		return &pb.UpdateProfileResponse{
+			Success: false,
+			Message: fmt.Sprintf("User %s does not exist", name),
+		}, nil
+	}
+
+	if req.GetEmail() != "" && !email.ValidAddress(req.GetEmail()) {
+		return &pb.UpdateProfileResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid email address %s", req.GetEmail()),
+		}, nil
+	}
+	if req.GetEmailEnabled() && req.GetEmail() == "" {
+		return &pb.UpdateProfileResponse{
+			Success: false,
+			Message: "An email address is required to enable email notifications",
+		}, nil
+	}
+	for _, kind := range req.GetEmailEvents() {
+		if !notificationKinds[kind] {
+			return &pb.UpdateProfileResponse{
+				Success: false,
+				Message: fmt.Sprintf("Unknown notification kind %s", kind),
+			}, nil
+		}
+	}
+
+	e, err := s.record(ctx, &events.ProfileUpdated{
+		Name:         name,
+		Email:        req.GetEmail(),
+		EmailEnabled: req.GetEmailEnabled(),
+		EmailEvents:  req.GetEmailEvents(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events.ApplyUser(s.users, e)
+
+	slog.InfoContext(ctx, "Profile updated", "user", name)
+	return &pb.UpdateProfileResponse{
+		Success: true,
+		Message: fmt.Sprintf("Profile of %s updated", name),
+		User:    s.userView(user),
+	}, nil
+}
+
+// wantsEmail reports whether a user has asked for a kind of notification by
+// email; user may be nil
+func wantsEmail(user *pb.User, kind notify.Kind) (string, bool) {
+	if user == nil || !user.EmailEnabled || user.Email == "" {
+		return "", false
+	}
+	if len(user.EmailEvents) == 0 {
+		return user.Email, true
+	}
+	for _, k := range user.EmailEvents {
+		if k == string(kind) {
+			return user.Email, true
+		}
+	}
+	return "", false
+}
+
+// isSuspended reports whether a moderator suspended the user
+func (s *AuctionServer) isSuspended(name string) bool {
+	s.usersMu.RLock()
+	defer s.usersMu.RUnlock()
+
+	user, exists := s.users[name]
+	return exists && user.Suspended
+}
+
+// userView copies a user for a response, filling in their roles; callers
+// hold s.usersMu
+func (s *AuctionServer) userView(user *pb.User) *pb.User {
+	view := proto.Clone(user).(*pb.User)
+	for _, r := range s.roles.Roles(user.Name) {
+		view.Roles = append(view.Roles, string(r))
+	}
+	return view
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/930r91na/Subasta-grpc/pkg/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// defaultRateLimits keep a single client from flooding the write paths while
+// leaving reads and the web UI's polling plenty of room
+const defaultRateLimits = "RegisterUser=10/m:5,AddProduct=1/s:5,PlaceBid=5/s:10,*=50/s:100"
+
+// RateLimiter throttles gRPC calls per authenticated user and per client
+// address
+type RateLimiter struct {
+	limiter *ratelimit.Limiter
+	// trusted proxies, such as the web server, may name the client they
+	// forward for in x-forwarded-for metadata
+	trusted map[string]bool
+}
+
+// NewRateLimiter creates a rate limiter enforcing limits, keyed by the short
+// method name, e.g. "PlaceBid". Calls from the trusted proxy addresses are
+// attributed to the client they forward for.
+func NewRateLimiter(limits ratelimit.Limits, trustedProxies []string) *RateLimiter {
+	trusted := make(map[string]bool)
+	for _, addr := range trustedProxies {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			trusted[addr] = true
+		}
+	}
+	return &RateLimiter{limiter: ratelimit.New(limits), trusted: trusted}
+}
+
+// clientAddr returns the address of the client behind a call
+func (rl *RateLimiter) clientAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	if rl.trusted[addr] {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			client, _, _ := strings.Cut(forwarded[0], ",")
+			if client = strings.TrimSpace(client); client != "" {
+				return client
+			}
+		}
+	}
+	return addr
+}
+
+// check admits a call or returns a ResourceExhausted error carrying a retry
+// hint, both as RetryInfo details and as a retry-after header in seconds.
+// user is the call's authenticated user, if any: a user a request merely
+// names is not limited, or anyone could spend that user's budget.
+func (rl *RateLimiter) check(ctx context.Context, method, user string) error {
+	var keys []string
+	if user != "" {
+		keys = append(keys, "user:"+user)
+	}
+	if addr := rl.clientAddr(ctx); addr != "" {
+		keys = append(keys, "addr:"+addr)
+	}
+
+	ok, wait := rl.limiter.Allow(path.Base(method), keys...)
+	if ok {
+		return nil
+	}
+
+	seconds := ratelimit.RetryAfterSeconds(wait)
+	grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+
+	st := status.New(codes.ResourceExhausted,
+		fmt.Sprintf("too many %s calls, retry in %ds", path.Base(method), seconds))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// UnaryInterceptor rejects calls over the rate limit
+func (rl *RateLimiter) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := rl.check(ctx, info.FullMethod, authenticatedUser(ctx, info.FullMethod)); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor rejects new streams over the rate limit. Only opening
+// the stream counts.
+func (rl *RateLimiter) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := rl.check(ss.Context(), info.FullMethod, authenticatedUser(ss.Context(), info.FullMethod)); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
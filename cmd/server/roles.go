@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/930r91na/Subasta-grpc/pkg/rbac"
+)
+
+// GrantRole gives a user or staff member a role
+func (a *AdminServer) GrantRole(ctx context.Context, req *pb.GrantRoleRequest) (*pb.GrantRoleResponse, error) {
+	roles := a.auction.roles
+	name := req.GetUser()
+	role := rbac.Role(req.GetRole())
+
+	if !roles.Policy().Known(role) {
+		return &pb.GrantRoleResponse{
+			Success: false,
+			Message: fmt.Sprintf("Unknown role %s", role),
+		}, nil
+	}
+	if roles.Has(name, role) {
+		return &pb.GrantRoleResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s already has the %s role", name, role),
+			Roles:   roleNames(roles.Roles(name)),
+		}, nil
+	}
+
+	e, err := a.auction.record(ctx, &events.RoleGranted{User: name, Role: string(role), Reason: req.GetReason(), Actor: adminFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	a.auction.applyRoles(e)
+
+	slog.InfoContext(ctx, "Role granted", "role", role, "user", name, "admin", adminFromContext(ctx), "reason", req.GetReason())
+	return &pb.GrantRoleResponse{
+		Success: true,
+		Message: fmt.Sprintf("%s granted the %s role", name, role),
+		Roles:   roleNames(roles.Roles(name)),
+	}, nil
+}
+
+// RevokeRole takes a role away from a user or staff member
+func (a *AdminServer) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	roles := a.auction.roles
+	name := req.GetUser()
+	role := rbac.Role(req.GetRole())
+
+	if name == adminFromContext(ctx) && role == rbac.RoleAdmin {
+		return &pb.RevokeRoleResponse{
+			Success: false,
+			Message: "Admins cannot revoke their own admin role",
+			Roles:   roleNames(roles.Roles(name)),
+		}, nil
+	}
+
+	if !roles.Has(name, role) {
+		return &pb.RevokeRoleResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s does not have the %s role", name, role),
+			Roles:   roleNames(roles.Roles(name)),
+		}, nil
+	}
+
+	e, err := a.auction.record(ctx, &events.RoleRevoked{User: name, Role: string(role), Reason: req.GetReason(), Actor: adminFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	a.auction.applyRoles(e)
+
+	slog.InfoContext(ctx, "Role revoked", "role", role, "user", name, "admin", adminFromContext(ctx), "reason", req.GetReason())
+	return &pb.RevokeRoleResponse{
+		Success: true,
+		Message: fmt.Sprintf("%s no longer has the %s role", name, role),
+		Roles:   roleNames(roles.Roles(name)),
+	}, nil
+}
+
+// ListRoles returns a user's roles and the permissions they grant
+func (a *AdminServer) ListRoles(ctx context.Context, req *pb.ListRolesRequest) (*pb.ListRolesResponse, error) {
+	roles := a.auction.roles
+	held := roles.Roles(req.GetUser())
+
+	seen := make(map[rbac.Permission]bool)
+	var permissions []string
+	for _, r := range held {
+		for _, perm := range roles.Policy()[r] {
+			if !seen[perm] {
+				seen[perm] = true
+				permissions = append(permissions, string(perm))
+			}
+		}
+	}
+
+	return &pb.ListRolesResponse{
+		Roles:       roleNames(held),
+		Permissions: permissions,
+	}, nil
+}
+
+func roleNames(roles []rbac.Role) []string {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, string(r))
+	}
+	return names
+}
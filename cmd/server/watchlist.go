@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+)
+
+// AddToWatchlist lets a user follow a product without bidding
+func (s *AuctionServer) AddToWatchlist(ctx context.Context, req *pb.AddToWatchlistRequest) (*pb.AddToWatchlistResponse, error) {
+	user := req.GetUser()
+	product := req.GetProduct()
+
+	s.usersMu.RLock()
+	_, exists := s.users[user]
+	s.usersMu.RUnlock()
+	if !exists {
+		return &pb.AddToWatchlistResponse{
+			Success: false,
+			Message: fmt.Sprintf("User %s does not exist", user),
+		}, nil
+	}
+
+	a := s.lockAuction(product)
+	if a == nil {
+		return &pb.AddToWatchlistResponse{
+			Success: false,
+			Message: fmt.Sprintf("Product %s does not exist", product),
+		}, nil
+	}
+	defer a.mu.Unlock()
+
+	if a.Watchers[user] {
+		return &pb.AddToWatchlistResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s is already on your watchlist", product),
+		}, nil
+	}
+
+	e, err := s.record(ctx, &events.WatchAdded{User: user, Product: product})
+	if err != nil {
+		return nil, err
+	}
+	a.apply(e)
+
+	slog.InfoContext(ctx, "Watching product", "user", user, "product", product)
+	return &pb.AddToWatchlistResponse{
+		Success: true,
+		Message: fmt.Sprintf("%s added to your watchlist", product),
+	}, nil
+}
+
+// RemoveFromWatchlist stops a user following a product
+func (s *AuctionServer) RemoveFromWatchlist(ctx context.Context, req *pb.RemoveFromWatchlistRequest) (*pb.RemoveFromWatchlistResponse, error) {
+	user := req.GetUser()
+	product := req.GetProduct()
+
+	a := s.lockAuction(product)
+	if a != nil {
+		defer a.mu.Unlock()
+	}
+	if a == nil || !a.Watchers[user] {
+		return &pb.RemoveFromWatchlistResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s is not on your watchlist", product),
+		}, nil
+	}
+
+	e, err := s.record(ctx, &events.WatchRemoved{User: user, Product: product})
+	if err != nil {
+		return nil, err
+	}
+	a.apply(e)
+
+	slog.InfoContext(ctx, "Stopped watching product", "user", user, "product", product)
+	return &pb.RemoveFromWatchlistResponse{
+		Success: true,
+		Message: fmt.Sprintf("%s removed from your watchlist", product),
+	}, nil
+}
+
+// ListWatchlist returns the products a user is watching, sorted by name
+func (s *AuctionServer) ListWatchlist(ctx context.Context, req *pb.ListWatchlistRequest) (*pb.ListWatchlistResponse, error) {
+	user := req.GetUser()
+	products := make([]*pb.ProductInfo, 0)
+	for _, a := range s.auctions() {
+		a.mu.Lock()
+		if a.Watchers[user] && !a.removed {
+			products = append(products, a.snapshot.Load())
+		}
+		a.mu.Unlock()
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Product < products[j].Product })
+
+	return &pb.ListWatchlistResponse{
+		Products: products,
+	}, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
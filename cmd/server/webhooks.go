@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/webhook"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Event types delivered to webhooks
+const (
+	eventUserRegistered = "user.registered"
+	eventProductListed  = "product.listed"
+	eventBidPlaced      = "bid.placed"
+	eventAuctionClosed  = "auction.closed"
+)
+
+// publish sends an auction event to the webhook subscribers
+func (s *AuctionServer) publish(eventType string, data interface{}) {
+	if err := s.webhooks.Publish(eventType, data); err != nil {
+		slog.Error("Failed to publish webhook event", "type", eventType, "error", err)
+	}
+}
+
+// RegisterWebhook subscribes an endpoint to auction events
+func (a *AdminServer) RegisterWebhook(ctx context.Context, req *pb.RegisterWebhookRequest) (*pb.RegisterWebhookResponse, error) {
+	if req.GetSecret() == "" {
+		return &pb.RegisterWebhookResponse{
+			Success: false,
+			Message: "A signing secret is required",
+		}, nil
+	}
+
+	sub, err := a.auction.webhooks.Subscribe(req.GetUrl(), req.GetSecret(), req.GetEvents())
+	if errors.Is(err, webhook.ErrInvalidURL) {
+		return &pb.RegisterWebhookResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid webhook URL %s", req.GetUrl()),
+		}, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to save webhooks", "error", err)
+	}
+
+	slog.InfoContext(ctx, "Webhook registered", "id", sub.ID, "url", sub.URL)
+	return &pb.RegisterWebhookResponse{
+		Success: true,
+		Message: fmt.Sprintf("Webhook %s registered", sub.ID),
+		Webhook: toProtoWebhook(sub),
+	}, nil
+}
+
+// ListWebhooks returns every webhook subscription
+func (a *AdminServer) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	subs := a.auction.webhooks.Subscriptions()
+
+	webhooks := make([]*pb.Webhook, 0, len(subs))
+	for _, sub := range subs {
+		webhooks = append(webhooks, toProtoWebhook(sub))
+	}
+	return &pb.ListWebhooksResponse{Webhooks: webhooks}, nil
+}
+
+// DeleteWebhook removes a webhook subscription
+func (a *AdminServer) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	err := a.auction.webhooks.Unsubscribe(req.GetId())
+	if errors.Is(err, webhook.ErrNotFound) {
+		return &pb.DeleteWebhookResponse{
+			Success: false,
+			Message: fmt.Sprintf("Webhook %s not found", req.GetId()),
+		}, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to save webhooks", "error", err)
+	}
+
+	slog.InfoContext(ctx, "Webhook deleted", "id", req.GetId())
+	return &pb.DeleteWebhookResponse{
+		Success: true,
+		Message: fmt.Sprintf("Webhook %s deleted", req.GetId()),
+	}, nil
+}
+
+// ListDeadLetters returns the deliveries that exhausted their retries
+func (a *AdminServer) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	dls := a.auction.webhooks.DeadLetters()
+
+	deadLetters := make([]*pb.DeadLetter, 0, len(dls))
+	for _, dl := range dls {
+		deadLetters = append(deadLetters, &pb.DeadLetter{
+			Id:        dl.ID,
+			WebhookId: dl.SubscriptionID,
+			EventId:   dl.Event.ID,
+			EventType: dl.Event.Type,
+			Attempts:  int32(dl.Attempts),
+			LastError: dl.LastError,
+			FailedAt:  timestamppb.New(dl.FailedAt),
+		})
+	}
+	return &pb.ListDeadLettersResponse{DeadLetters: deadLetters}, nil
+}
+
+// ReplayWebhook delivers one or all dead-lettered events again
+func (a *AdminServer) ReplayWebhook(ctx context.Context, req *pb.ReplayWebhookRequest) (*pb.ReplayWebhookResponse, error) {
+	ids := []string{req.GetDeadLetterId()}
+	if req.GetAll() {
+		ids = ids[:0]
+		for _, dl := range a.auction.webhooks.DeadLetters() {
+			ids = append(ids, dl.ID)
+		}
+	}
+
+	replayed := int32(0)
+	for _, id := range ids {
+		err := a.auction.webhooks.Replay(id)
+		if errors.Is(err, webhook.ErrNotFound) && !req.GetAll() {
+			return &pb.ReplayWebhookResponse{
+				Success: false,
+				Message: fmt.Sprintf("Dead letter %s cannot be replayed: %v", id, err),
+			}, nil
+		}
+		if errors.Is(err, webhook.ErrNotFound) || errors.Is(err, webhook.ErrStopped) {
+			slog.ErrorContext(ctx, "Failed to replay dead letter", "id", id, "error", err)
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to save webhooks", "error", err)
+		}
+		replayed++
+	}
+
+	slog.InfoContext(ctx, "Replayed webhook deliveries", "count", replayed)
+	return &pb.ReplayWebhookResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Replayed %d deliveries", replayed),
+		Replayed: replayed,
+	}, nil
+}
+
+func toProtoWebhook(sub webhook.Subscription) *pb.Webhook {
+	return &pb.Webhook{
+		Id:        sub.ID,
+		Url:       sub.URL,
+		Events:    sub.Events,
+		CreatedAt: timestamppb.New(sub.CreatedAt),
+	}
+}
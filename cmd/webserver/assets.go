@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+// indexFile is the UI's page, served at /
+const indexFile = "templates/index.html"
+
+// codings are the content codings assets are compressed with, the most
+// compact first
+var codings = []string{"br", "gzip"}
+
+// assets serves the web UI from a file system: the page at / and the files
+// under static/ at /static/. Files are sent with an ETag of their content
+// and compressed with brotli or gzip for browsers that accept it.
+type assets struct {
+	fsys fs.FS
+	// cached holds every file, loaded up front; it is nil when files are
+	// read on every request instead, so edits show on reload
+	cached map[string]*asset
+}
+
+// asset is a file ready to be served
+type asset struct {
+	contentType string
+	etag        string
+	// encoded holds the file by content coding, "" being the file as is;
+	// only codings that make it smaller are kept
+	encoded map[string][]byte
+}
+
+// newAssets serves the files in fsys. With cache, they are all read and
+// compressed now, which suits files that never change such as embedded
+// ones.
+func newAssets(fsys fs.FS, cache bool) (*assets, error) {
+	a := &assets{fsys: fsys}
+	if !cache {
+		return a, nil
+	}
+	a.cached = make(map[string]*asset)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		f, err := loadAsset(fsys, name)
+		if err != nil {
+			return err
+		}
+		a.cached[name] = f
+		return nil
+	})
+	return a, err
+}
+
+// loadAsset reads a file and compresses it if it is text
+func loadAsset(fsys fs.FS, name string) (*asset, error) {
+	if info, err := fs.Stat(fsys, name); err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		return nil, fs.ErrNotExist
+	}
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	f := &asset{
+		contentType: mime.TypeByExtension(path.Ext(name)),
+		etag:        hex.EncodeToString(sum[:8]),
+		encoded:     map[string][]byte{"": data},
+	}
+	if f.contentType == "" {
+		f.contentType = http.DetectContentType(data)
+	}
+	if !compressible(f.contentType) {
+		return f, nil
+	}
+	for _, coding := range codings {
+		compressed, err := compress(coding, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(compressed) < len(data) {
+			f.encoded[coding] = compressed
+		}
+	}
+	return f, nil
+}
+
+// compressible reports whether content is text, which compresses well
+func compressible(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "javascript") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "svg")
+}
+
+// compress returns data compressed with a content coding
+func compress(coding string, data []byte) ([]byte, error) {
+	var b bytes.Buffer
+	var w io.WriteCloser
+	switch coding {
+	case "br":
+		w = brotli.NewWriterLevel(&b, brotli.BestCompression)
+	case "gzip":
+		w, _ = gzip.NewWriterLevel(&b, gzip.BestCompression)
+	default:
+		return nil, errors.New("unknown content coding " + coding)
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// negotiate picks the most compact coding of f that a request's
+// Accept-Encoding allows, or "" for the file as is
+func negotiate(acceptEncoding string, f *asset) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(coding))] = true
+	}
+	for _, coding := range codings {
+		if _, ok := f.encoded[coding]; ok && accepted[coding] {
+			return coding
+		}
+	}
+	return ""
+}
+
+// lookup returns a file to serve
+func (a *assets) lookup(name string) (*asset, error) {
+	if a.cached == nil {
+		return loadAsset(a.fsys, name)
+	}
+	f, ok := a.cached[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return f, nil
+}
+
+func (a *assets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var name string
+	switch {
+	case r.URL.Path == "/":
+		name = indexFile
+	case r.URL.Path == "/"+indexFile:
+		// Where the page used to be served
+		http.Redirect(w, r, "/", http.StatusMovedPermanently)
+		return
+	case strings.HasPrefix(r.URL.Path, "/static/"):
+		name = strings.TrimPrefix(r.URL.Path, "/")
+	}
+	if name == "" || !fs.ValidPath(name) {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f, err := a.lookup(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to load web asset", "name", name, "error", err)
+		http.Error(w, "failed to load "+name, http.StatusInternalServerError)
+		return
+	}
+
+	coding := negotiate(r.Header.Get("Accept-Encoding"), f)
+	etag := f.etag
+	if coding != "" {
+		w.Header().Set("Content-Encoding", coding)
+		etag += "-" + coding
+	}
+	w.Header().Set("Content-Type", f.contentType)
+	w.Header().Add("Vary", "Accept-Encoding")
+	w.Header().Set("ETag", `"`+etag+`"`)
+	// The files keep their names when they change, so browsers check back
+	// every time; unchanged files cost a 304 Not Modified
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(f.encoded[coding]))
+}
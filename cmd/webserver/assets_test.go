@@ -0,0 +1,91 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestAssets(t *testing.T) {
+	script := []byte(strings.Repeat("console.log('bid');\n", 100))
+	image := []byte("\x89PNG\r\n\x1a\n not worth compressing")
+	fsys := fstest.MapFS{
+		"static/app.js":   {Data: script},
+		"static/logo.png": {Data: image},
+	}
+	etag := func(data []byte, coding string) string {
+		sum := sha256.Sum256(data)
+		tag := hex.EncodeToString(sum[:8])
+		if coding != "" {
+			tag += "-" + coding
+		}
+		return `"` + tag + `"`
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		ifNoneMatch    string
+		wantStatus     int
+		wantEncoding   string
+	}{
+		{"identity", "/static/app.js", "", "", http.StatusOK, ""},
+		{"br over gzip", "/static/app.js", "gzip, deflate, br", "", http.StatusOK, "br"},
+		{"gzip only", "/static/app.js", "gzip", "", http.StatusOK, "gzip"},
+		{"case and spaces", "/static/app.js", " GZIP ;q=0.5", "", http.StatusOK, "gzip"},
+		{"br refused", "/static/app.js", "br;q=0, gzip", "", http.StatusOK, "gzip"},
+		{"all refused", "/static/app.js", "br;q=0, gzip;q=0.0", "", http.StatusOK, ""},
+		{"not compressed", "/static/logo.png", "br, gzip", "", http.StatusOK, ""},
+		{"unchanged", "/static/app.js", "br", etag(script, "br"), http.StatusNotModified, "br"},
+		{"unchanged among others", "/static/app.js", "", `"old", ` + etag(script, ""), http.StatusNotModified, ""},
+		{"other coding's tag", "/static/app.js", "gzip", etag(script, "br"), http.StatusOK, "gzip"},
+		{"changed", "/static/logo.png", "", etag(script, ""), http.StatusOK, ""},
+		{"missing", "/static/none.js", "br", "", http.StatusNotFound, ""},
+	}
+
+	for _, cache := range []bool{true, false} {
+		a, err := newAssets(fsys, cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+				if tt.acceptEncoding != "" {
+					req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+				}
+				if tt.ifNoneMatch != "" {
+					req.Header.Set("If-None-Match", tt.ifNoneMatch)
+				}
+				w := httptest.NewRecorder()
+				a.ServeHTTP(w, req)
+
+				if w.Code != tt.wantStatus {
+					t.Fatalf("cache %v: status %d, want %d", cache, w.Code, tt.wantStatus)
+				}
+				if w.Code == http.StatusNotFound {
+					return
+				}
+				// A 304 has no body to encode, but its ETag names the coding
+				if got := w.Header().Get("Content-Encoding"); w.Code == http.StatusOK && got != tt.wantEncoding {
+					t.Errorf("cache %v: Content-Encoding %q, want %q", cache, got, tt.wantEncoding)
+				}
+				data := script
+				if strings.HasSuffix(tt.path, ".png") {
+					data = image
+				}
+				if got, want := w.Header().Get("ETag"), etag(data, tt.wantEncoding); got != want {
+					t.Errorf("cache %v: ETag %s, want %s", cache, got, want)
+				}
+				if w.Code == http.StatusOK && tt.wantEncoding == "" && w.Body.String() != string(data) {
+					t.Errorf("cache %v: body differs from the file", cache)
+				}
+			})
+		}
+	}
+}
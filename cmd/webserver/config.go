@@ -0,0 +1,149 @@
+package main
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/930r91na/Subasta-grpc/pkg/config"
+	"github.com/930r91na/Subasta-grpc/pkg/ratelimit"
+	"github.com/930r91na/Subasta-grpc/pkg/tracing"
+)
+
+// Config holds the web server's settings; see package config for how they
+// are loaded
+type Config struct {
+	Network networkConfig `yaml:"network"`
+	TLS     tlsConfig     `yaml:"tls"`
+	GRPCTLS grpcTLSConfig `yaml:"grpc_tls"`
+	Web     webConfig     `yaml:"web"`
+	Log     logConfig     `yaml:"log"`
+	Tracing tracingConfig `yaml:"tracing"`
+}
+
+// networkConfig is where the web server listens and the auction server it
+// calls
+type networkConfig struct {
+	Addr            string        `yaml:"addr" flag:"addr" usage:"address to serve the web UI and API on"`
+	Server          string        `yaml:"server" flag:"server" usage:"address of the auction server's gRPC API"`
+	CallTimeout     time.Duration `yaml:"call_timeout" flag:"call-timeout" usage:"how long an API request may wait for the auction server"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" flag:"shutdown-timeout" usage:"how long requests in flight may run on after SIGTERM before they are cut off"`
+}
+
+// tlsConfig secures the web server's own connections
+type tlsConfig struct {
+	Cert       string `yaml:"cert" flag:"tls-cert" usage:"PEM certificate to serve HTTPS with (HTTP when empty)"`
+	Key        string `yaml:"key" flag:"tls-key" usage:"PEM private key of the certificate"`
+	CA         string `yaml:"ca" flag:"tls-ca" usage:"PEM CA certificates that browsers' client certificates are verified against"`
+	ClientAuth string `yaml:"client_auth" flag:"tls-client-auth" usage:"client certificates, whose name is passed on as the user: none, request (verified when sent) or require"`
+}
+
+// grpcTLSConfig secures calls to the auction server
+type grpcTLSConfig struct {
+	CA   string `yaml:"ca" flag:"grpc-ca" usage:"PEM CA certificates the auction server is verified against (plaintext when empty)"`
+	Cert string `yaml:"cert" flag:"grpc-cert" usage:"PEM certificate identifying the web server to the auction server"`
+	Key  string `yaml:"key" flag:"grpc-key" usage:"PEM private key of the certificate"`
+}
+
+// webConfig is what the web server serves and to whom
+type webConfig struct {
+	Dir        string `yaml:"dir" flag:"web-dir" usage:"serve the web UI from this directory, e.g. \"web\", re-reading files on every request for live editing (the copy built into the binary when empty)"`
+	RateLimits string `yaml:"rate_limits" flag:"rate-limits" usage:"per-method limits for each client address and certificate user as \"Method=calls/unit[:burst],...\", \"*\" for the rest"`
+}
+
+// logConfig sets what is logged
+type logConfig struct {
+	Level string `yaml:"level" flag:"log-level" usage:"least severe level logged: debug, info, warn or error"`
+}
+
+// tracingConfig sets where traces go
+type tracingConfig struct {
+	Exporter string `yaml:"exporter" flag:"trace-exporter" usage:"where to send traces: \"stdout\", or \"otlp\" for the collector in $OTEL_EXPORTER_OTLP_ENDPOINT (disabled when empty)"`
+}
+
+// defaultConfig returns the settings of a web server run on a laptop
+func defaultConfig() *Config {
+	cfg := &Config{}
+	cfg.Network.Addr = ":8080"
+	cfg.Network.Server = "localhost:50051"
+	cfg.Network.CallTimeout = time.Second
+	cfg.Network.ShutdownTimeout = 10 * time.Second
+	cfg.Web.RateLimits = defaultRateLimits
+	cfg.Log.Level = "info"
+	return cfg
+}
+
+// Validate reports every setting that is out of range or unreadable
+func (c *Config) Validate() error {
+	var errs config.Errors
+
+	if err := config.CheckAddr(c.Network.Addr); err != nil {
+		errs.Addf("network.addr", "%v", err)
+	}
+	if err := config.CheckAddr(c.Network.Server); err != nil {
+		errs.Addf("network.server", "%v", err)
+	}
+	if c.Network.CallTimeout <= 0 {
+		errs.Addf("network.call_timeout", "must be positive")
+	}
+	if c.Network.ShutdownTimeout <= 0 {
+		errs.Addf("network.shutdown_timeout", "must be positive")
+	}
+
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		errs.Addf("tls.key", "cert and key must be set together")
+	}
+	if (c.GRPCTLS.Cert == "") != (c.GRPCTLS.Key == "") {
+		errs.Addf("grpc_tls.key", "cert and key must be set together")
+	}
+	for _, f := range []struct{ key, file string }{
+		{"tls.cert", c.TLS.Cert}, {"tls.key", c.TLS.Key}, {"tls.ca", c.TLS.CA},
+		{"grpc_tls.ca", c.GRPCTLS.CA}, {"grpc_tls.cert", c.GRPCTLS.Cert}, {"grpc_tls.key", c.GRPCTLS.Key},
+	} {
+		if f.file == "" {
+			continue
+		}
+		if _, err := os.Stat(f.file); err != nil {
+			errs.Addf(f.key, "%v", err)
+		}
+	}
+	clientAuth, err := certs.ParseClientAuth(c.TLS.ClientAuth)
+	if err != nil {
+		errs.Addf("tls.client_auth", "%v", err)
+	}
+	if c.TLS.Cert == "" && (c.TLS.CA != "" || clientAuth != tls.NoClientCert) {
+		errs.Addf("tls.cert", "must be set to verify clients")
+	}
+	if c.TLS.CA == "" && clientAuth != tls.NoClientCert {
+		errs.Addf("tls.ca", "must be set to verify clients")
+	}
+	if c.GRPCTLS.CA == "" && c.GRPCTLS.Cert != "" {
+		errs.Addf("grpc_tls.ca", "must be set to present a certificate")
+	}
+
+	if c.Web.Dir != "" {
+		if info, err := os.Stat(c.Web.Dir); err != nil {
+			errs.Addf("web.dir", "%v", err)
+		} else if !info.IsDir() {
+			errs.Addf("web.dir", "%s is not a directory", c.Web.Dir)
+		}
+	}
+	if _, err := ratelimit.ParseLimits(c.Web.RateLimits); err != nil {
+		errs.Addf("web.rate_limits", "%v", err)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs.Addf("log.level", "%v", err)
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs.Addf("tracing.exporter", "%q is not stdout or otlp", c.Tracing.Exporter)
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// maxRequestBytes bounds the body of an API request
+const maxRequestBytes = 1 << 20
+
+// The JSON of requests and responses follows the proto's field names, so
+// the API speaks the same snake_case as the .proto file. Unknown fields are
+// rejected rather than ignored, and responses list every field, zero or
+// not, so clients need not know the proto's defaults.
+var (
+	unmarshalJSON = protojson.UnmarshalOptions{}
+	marshalJSON   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// jsonCodec encodes the Connect protocol's JSON with the API's options, in
+// place of Connect's own. An empty body is an empty request.
+type jsonCodec struct {
+	name string
+}
+
+func (c jsonCodec) Name() string {
+	return c.name
+}
+
+func (c jsonCodec) Marshal(m any) ([]byte, error) {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a proto message", m)
+	}
+	return marshalJSON.Marshal(msg)
+}
+
+func (c jsonCodec) Unmarshal(data []byte, m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T is not a proto message", m)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return unmarshalJSON.Unmarshal(data, msg)
+}
+
+// newRequest makes the message a request to a method is decoded into from
+// the method's descriptor, which the handler has as its schema
+func newRequest(spec connect.Spec, m any) error {
+	md, ok := spec.Schema.(protoreflect.MethodDescriptor)
+	if !ok {
+		return fmt.Errorf("%s has no method descriptor", spec.Procedure)
+	}
+	msg, ok := m.(*dynamicpb.Message)
+	if !ok {
+		return fmt.Errorf("%s: %T is not a dynamic message", spec.Procedure, m)
+	}
+	*msg = *dynamicpb.NewMessage(md.Input())
+	return nil
+}
+
+// registerGateway serves every unary and server streaming method of a gRPC
+// service over HTTP at /<service>/<method>, relaying the calls to conn. The
+// methods speak the Connect protocol, gRPC-Web and gRPC, so generated
+// clients work against them, and unary methods are also plain JSON over
+// POST. Methods and fields added to the proto are served without further
+// changes here. Client streaming methods cannot be relayed over HTTP, so a
+// service with any is refused.
+func registerGateway(conn grpc.ClientConnInterface, sd protoreflect.ServiceDescriptor) error {
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		if md.IsStreamingClient() {
+			return fmt.Errorf("%s is client streaming, which the web server cannot serve", md.FullName())
+		}
+
+		route := fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())
+		opts := []connect.HandlerOption{
+			connect.WithSchema(md),
+			connect.WithRequestInitializer(newRequest),
+			connect.WithCodec(jsonCodec{name: "json"}),
+			connect.WithCodec(jsonCodec{name: "json; charset=utf-8"}),
+			connect.WithReadMaxBytes(maxRequestBytes),
+		}
+		var h *connect.Handler
+		if md.IsStreamingServer() {
+			h = connect.NewServerStreamHandler(route, relayStream(conn, route, md), opts...)
+		} else {
+			h = connect.NewUnaryHandler(route, relayUnary(conn, route, md), opts...)
+		}
+		http.HandleFunc(route, instrumented(logged(corsMiddleware(rateLimited(forwarded(h))))))
+	}
+	return nil
+}
+
+// forwarded passes who a request came from on to the calls made for it
+func forwarded(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(outgoing(r.Context(), r)))
+	}
+}
+
+// relayUnary returns the implementation of a unary method calling it on
+// the auction server
+func relayUnary(conn grpc.ClientConnInterface, fullMethod string, md protoreflect.MethodDescriptor) func(context.Context, *connect.Request[dynamicpb.Message]) (*connect.Response[dynamicpb.Message], error) {
+	return func(ctx context.Context, req *connect.Request[dynamicpb.Message]) (*connect.Response[dynamicpb.Message], error) {
+		ctx, cancel := context.WithTimeout(ctx, callTimeout)
+		defer cancel()
+
+		out := dynamicpb.NewMessage(md.Output())
+		var header metadata.MD
+		if err := conn.Invoke(ctx, fullMethod, req.Msg, out, grpc.Header(&header)); err != nil {
+			return nil, connectError(err)
+		}
+		resp := connect.NewResponse(out)
+		copyReplayed(resp.Header(), header)
+		return resp, nil
+	}
+}
+
+// relayStream returns the implementation of a server streaming method
+// relaying the auction server's stream until either side ends it or the web
+// server shuts down
+func relayStream(conn grpc.ClientConnInterface, fullMethod string, md protoreflect.MethodDescriptor) func(context.Context, *connect.Request[dynamicpb.Message], *connect.ServerStream[dynamicpb.Message]) error {
+	return func(ctx context.Context, req *connect.Request[dynamicpb.Message], stream *connect.ServerStream[dynamicpb.Message]) error {
+		ctx, cancel := untilShutdown(ctx)
+		defer cancel()
+
+		cs, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+		if err != nil {
+			return connectError(err)
+		}
+		if err := cs.SendMsg(req.Msg); err != nil {
+			return connectError(err)
+		}
+		if err := cs.CloseSend(); err != nil {
+			return connectError(err)
+		}
+		for {
+			msg := dynamicpb.NewMessage(md.Output())
+			if err := cs.RecvMsg(msg); err == io.EOF {
+				return nil
+			} else if err != nil {
+				if pushes.Err() != nil {
+					return connect.NewError(connect.CodeUnavailable, errors.New("web server shutting down"))
+				}
+				return connectError(err)
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// connectError passes a failed call's status on with its code, message and
+// details, and Retry-After when the call was rate limited
+func connectError(err error) error {
+	st := status.Convert(err)
+	cerr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, d := range st.Proto().GetDetails() {
+		if detail, err := connect.NewErrorDetail(d); err == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+	if seconds, ok := retryAfter(st); ok {
+		cerr.Meta().Set("Retry-After", seconds)
+	}
+	return cerr
+}
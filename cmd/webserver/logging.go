@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fatal logs an error the web server cannot start with and exits
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// logged names an API request with a request ID, which the response
+// returns in X-Request-ID, and logs it once answered. A caller may pick the
+// ID itself by sending the header.
+func logged(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := logging.AcceptRequestID(r.Header.Get(logging.Header))
+		w.Header().Set(logging.Header, id)
+		ctx := logging.WithRequestID(r.Context(), id)
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(rec, r.WithContext(ctx))
+
+		level := slog.LevelInfo
+		if rec.code >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(ctx, level, "Request handled",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.code,
+			"duration_ms", float64(time.Since(start).Microseconds())/1000,
+			"client", clientAddr(r),
+		)
+	}
+}
+
+// loggingClientInterceptor logs calls to the auction server under the
+// request ID they are made for
+func loggingClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	code := status.Code(err)
+	level := slog.LevelDebug
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		level = slog.LevelError
+	default:
+		level = slog.LevelInfo
+	}
+	slog.Log(ctx, level, "Call made",
+		"method", path.Base(method),
+		"peer", cc.Target(),
+		"code", code.String(),
+		"duration_ms", float64(time.Since(start).Microseconds())/1000,
+	)
+	return err
+}
@@ -2,202 +2,356 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
-	"path/filepath"
+	"os"
+	"os/signal"
+	"path"
+	"strconv"
+	"syscall"
 	"time"
 
 	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/930r91na/Subasta-grpc/pkg/config"
+	"github.com/930r91na/Subasta-grpc/pkg/ledger"
+	"github.com/930r91na/Subasta-grpc/pkg/logging"
+	"github.com/930r91na/Subasta-grpc/pkg/ratelimit"
+	"github.com/930r91na/Subasta-grpc/pkg/tracing"
+	"github.com/930r91na/Subasta-grpc/web"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var grpcClient pb.AuctionServiceClient
 
+// limiter throttles API calls per client address and user
+var limiter *ratelimit.Limiter
+
+// callTimeout bounds each call to the auction server
+var callTimeout = time.Second
+
+// defaultRateLimits hold each browser to the gRPC server's limits on
+// writes, and to fewer of the other calls than the server allows a client:
+// the web UI's polling of the catalog and notifications needs only a few a
+// second
+const defaultRateLimits = "RegisterUser=10/m:5,AddProduct=1/s:5,PlaceBid=5/s:10,*=20/s:40"
+
 func main() {
-	// Connect to gRPC server
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cfg := defaultConfig()
+	printConfig, err := config.Load(cfg, "webserver", "AUCTION_WEB_", os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if printConfig {
+		config.Print(os.Stdout, cfg)
+	}
 	if err != nil {
-		log.Fatalf("Failed to connect: %v", err)
+		fatal("Invalid configuration", "error", err)
+	}
+	if printConfig {
+		return
 	}
-	defer conn.Close()
-	grpcClient = pb.NewAuctionServiceClient(conn)
 
-	// Enable CORS for all routes
-	http.HandleFunc("/auction.AuctionService/RegisterUser", corsMiddleware(handleRegisterUser))
-	http.HandleFunc("/auction.AuctionService/GetCatalog", corsMiddleware(handleGetCatalog))
-	http.HandleFunc("/auction.AuctionService/PlaceBid", corsMiddleware(handlePlaceBid))
-	http.HandleFunc("/auction.AuctionService/AddProduct", corsMiddleware(handleAddProduct))
-	http.HandleFunc("/auction.AuctionService/GetProduct", corsMiddleware(handleGetProduct))
+	// The settings are valid, so the level is known
+	var level slog.Level
+	level.UnmarshalText([]byte(cfg.Log.Level))
+	slog.SetDefault(logging.New(os.Stderr, level))
+	callTimeout = cfg.Network.CallTimeout
 
-	// Serve static files from web directory (relative to where you run the command)
-	// This should be run from the project root
-	webDir, err := filepath.Abs("web")
+	rateLimits, err := ratelimit.ParseLimits(cfg.Web.RateLimits)
 	if err != nil {
-		log.Fatalf("Failed to get web directory path: %v", err)
+		fatal("Invalid rate limits", "error", err)
 	}
+	limiter = ratelimit.New(rateLimits)
+	registerMetrics()
 
-	fs := http.FileServer(http.Dir(webDir))
-	http.Handle("/", fs)
+	shutdownTracing, err := tracing.Setup(context.Background(), "auction-web", cfg.Tracing.Exporter)
+	if err != nil {
+		fatal("Failed to set up tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
 
-	log.Println("🚀 Web UI Server started on http://localhost:8080")
-	log.Printf("📂 Serving files from: %s", webDir)
-	log.Println("📌 Access the UI at: http://localhost:8080/templates/index.html")
-	log.Fatal(http.ListenAndServe(":8080", nil))
-}
+	// Connect to gRPC server, passing the trace of each request on
+	creds, err := certs.ClientCredentials(cfg.GRPCTLS.CA, cfg.GRPCTLS.Cert, cfg.GRPCTLS.Key)
+	if err != nil {
+		fatal("Failed to load certificates for the auction server", "error", err)
+	}
+	conn, err := grpc.Dial(cfg.Network.Server,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(metricsClientInterceptor, loggingClientInterceptor))
+	if err != nil {
+		fatal("Failed to connect", "error", err)
+	}
+	defer conn.Close()
+	grpcClient = pb.NewAuctionServiceClient(conn)
 
-// CORS middleware
-func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	// Serve the auction service's methods as JSON, derived from the proto
+	if err := registerGateway(conn, pb.File_auction_proto.Services().ByName("AuctionService")); err != nil {
+		fatal("Failed to set up the API", "error", err)
+	}
+	http.HandleFunc("/events", instrumented(logged(corsMiddleware(rateLimited(handleEvents)))))
+	http.HandleFunc("/ws", instrumented(logged(corsMiddleware(rateLimited(handleWebSocket)))))
+	http.HandleFunc("/ledger/statement.csv", instrumented(logged(corsMiddleware(rateLimited(handleExportStatement)))))
+	http.Handle("/metrics", promhttp.Handler())
+
+	// Serve the UI built into the binary, or from disk for live editing
+	var ui *assets
+	uiSource := "embedded"
+	if cfg.Web.Dir == "" {
+		ui, err = newAssets(web.FS, true)
+	} else {
+		uiSource = cfg.Web.Dir
+		ui, err = newAssets(os.DirFS(cfg.Web.Dir), false)
+	}
+	if err != nil {
+		fatal("Failed to load the web UI", "error", err)
+	}
+	http.Handle("/", ui)
+
+	// Serve HTTPS if there is a certificate; the files are read again
+	// whenever they change
+	srv := &http.Server{Addr: cfg.Network.Addr}
+	srv.RegisterOnShutdown(stopPushes)
+	// gRPC clients speak HTTP/2, which without TLS they start unannounced
+	srv.Protocols = new(http.Protocols)
+	srv.Protocols.SetHTTP1(true)
+	srv.Protocols.SetHTTP2(true)
+	srv.Protocols.SetUnencryptedHTTP2(true)
+	scheme := "http"
+	if cfg.TLS.Cert != "" {
+		tlsFiles, err := certs.NewReloader(cfg.TLS.Cert, cfg.TLS.Key, cfg.TLS.CA)
+		if err != nil {
+			fatal("Failed to load certificates", "error", err)
+		}
+		clientAuth, _ := certs.ParseClientAuth(cfg.TLS.ClientAuth)
+		srv.TLSConfig = tlsFiles.ServerConfig(clientAuth, "h2", "http/1.1")
+		scheme = "https"
+	}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+	host, port, _ := net.SplitHostPort(cfg.Network.Addr)
+	if host == "" {
+		host = "localhost"
+	}
+	slog.Info("Web UI server started", "addr", cfg.Network.Addr, "ui_files", uiSource,
+		"ui", scheme+"://"+net.JoinHostPort(host, port)+"/")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		serve := srv.ListenAndServe
+		if srv.TLSConfig != nil {
+			serve = func() error { return srv.ListenAndServeTLS("", "") }
+		}
+		if err := serve(); err != http.ErrServerClosed {
+			fatal("Failed to serve", "error", err)
 		}
+	}()
 
-		next(w, r)
+	<-ctx.Done()
+	stop()
+	slog.Info("Shutting down", "timeout", cfg.Network.ShutdownTimeout.String())
+
+	// Stop accepting requests and let those in flight finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Network.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Requests still in flight at the shutdown timeout; cutting them off", "error", err)
+		srv.Close()
 	}
+	slog.Info("Web UI server stopped")
 }
 
-func handleRegisterUser(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Name string `json:"name"`
+// writeGRPCError reports a failed gRPC call with the closest HTTP status
+func writeGRPCError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	code := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+		if seconds, ok := retryAfter(st); ok {
+			w.Header().Set("Retry-After", seconds)
+		}
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.FailedPrecondition, codes.Aborted:
+		code = http.StatusConflict
+	case codes.DeadlineExceeded:
+		code = http.StatusGatewayTimeout
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
 	}
-	json.NewDecoder(r.Body).Decode(&req)
+	http.Error(w, st.Message(), code)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+// retryAfter returns the seconds a rate limited call says to wait before
+// retrying, as a Retry-After header
+func retryAfter(st *status.Status) (string, bool) {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return strconv.Itoa(ratelimit.RetryAfterSeconds(info.GetRetryDelay().AsDuration())), true
+		}
+	}
+	return "", false
+}
 
-	resp, err := grpcClient.RegisterUser(ctx, &pb.RegisterUserRequest{Name: req.Name})
+// clientAddr returns the address of the client making a request
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return r.RemoteAddr
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": resp.Success,
-		"message": resp.Message,
-	})
+	return host
 }
 
-func handleGetCatalog(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	resp, err := grpcClient.GetCatalog(ctx, &pb.GetCatalogRequest{})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+// clientUser returns the user of a browser's verified certificate, or ""
+func clientUser(r *http.Request) string {
+	if r.TLS == nil {
+		return ""
 	}
+	return certs.PeerName(*r.TLS)
+}
 
-	products := make([]map[string]interface{}, 0)
-	for _, p := range resp.Products {
-		products = append(products, map[string]interface{}{
-			"seller":        p.Seller,
-			"product":       p.Product,
-			"initial_price": p.InitialPrice,
-			"current_price": p.CurrentPrice,
-		})
+// rateLimited rejects requests over the rate limit of the client's address
+// or user with 429 Too Many Requests and a Retry-After header. The user is
+// known from a verified certificate; a user a request merely names is not
+// limited, or anyone could spend that user's budget.
+func rateLimited(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		method := path.Base(r.URL.Path)
+		keys := []string{"addr:" + clientAddr(r)}
+		if user := clientUser(r); user != "" {
+			keys = append(keys, "user:"+user)
+		}
+		if ok, wait := limiter.Allow(method, keys...); !ok {
+			seconds := ratelimit.RetryAfterSeconds(wait)
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			http.Error(w, fmt.Sprintf("too many %s requests, retry in %ds", method, seconds),
+				http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"products": products,
-	})
+// callContext returns the context for a gRPC call made on behalf of r,
+// bounded by the call timeout
+func callContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(outgoing(r.Context(), r), callTimeout)
 }
 
-func handlePlaceBid(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Buyer   string  `json:"buyer"`
-		Product string  `json:"product"`
-		Amount  float64 `json:"amount"`
+// outgoing returns ctx with the metadata of calls made on behalf of r. The
+// gRPC server limits calls per client, so the client address is forwarded,
+// along with the request ID and the request's Idempotency-Key if it has one.
+func outgoing(ctx context.Context, r *http.Request) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx,
+		"x-forwarded-for", clientAddr(r),
+		logging.MetadataKey, logging.RequestID(r.Context()))
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "idempotency-key", key)
 	}
-	json.NewDecoder(r.Body).Decode(&req)
+	// The auction server holds a browser with a certificate to its user
+	if user := clientUser(r); user != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-client-user", user)
+	}
+	return ctx
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+// copyReplayed marks responses the gRPC server replayed for a retried
+// idempotency key
+func copyReplayed(h http.Header, header metadata.MD) {
+	if len(header.Get("idempotent-replayed")) > 0 {
+		h.Set("Idempotent-Replayed", "true")
+	}
+}
+
+// CORS middleware
+func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
+		// Connect and gRPC-Web clients send their own headers and read the
+		// call's status from the response's
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Idempotency-Key, X-Request-ID, traceparent, tracestate, "+
+			"Connect-Protocol-Version, Connect-Timeout-Ms, Grpc-Timeout, X-Grpc-Web, X-User-Agent")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Idempotent-Replayed, Retry-After, "+
+			"Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin")
 
-	resp, err := grpcClient.PlaceBid(ctx, &pb.PlaceBidRequest{
-		Buyer:   req.Buyer,
-		Product: req.Product,
-		Amount:  float32(req.Amount),
-	})
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
 
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		next(w, r)
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success":       resp.Success,
-		"message":       resp.Message,
-		"current_price": resp.CurrentPrice,
-	})
 }
 
-func handleAddProduct(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Seller       string  `json:"seller"`
-		Product      string  `json:"product"`
-		InitialPrice float64 `json:"initial_price"`
+// handleExportStatement serves an account statement as a CSV download,
+// e.g. GET /ledger/statement.csv?account=Alice. The auction server only
+// hands out a statement to its owner or to staff allowed to inspect.
+func handleExportStatement(w http.ResponseWriter, r *http.Request) {
+	account := r.URL.Query().Get("account")
+	if account == "" {
+		http.Error(w, "account is required", http.StatusBadRequest)
+		return
 	}
-	json.NewDecoder(r.Body).Decode(&req)
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	resp, err := grpcClient.AddProduct(ctx, &pb.AddProductRequest{
-		Seller:       req.Seller,
-		Product:      req.Product,
-		InitialPrice: float32(req.InitialPrice),
-	})
 
+	statement, err := fetchStatement(r, account)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeGRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": resp.Success,
-		"message": resp.Message,
-	})
-}
-
-func handleGetProduct(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Product string `json:"product"`
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "statement.csv"))
+	if err := statement.WriteCSV(w); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to write statement CSV", "error", err)
 	}
-	json.NewDecoder(r.Body).Decode(&req)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+// fetchStatement loads a statement over gRPC and converts it back into the
+// ledger representation used for rendering
+func fetchStatement(r *http.Request, account string) (ledger.Statement, error) {
+	ctx, cancel := callContext(r)
 	defer cancel()
 
-	resp, err := grpcClient.GetProduct(ctx, &pb.GetProductRequest{Product: req.Product})
+	resp, err := grpcClient.GetStatement(ctx, &pb.GetStatementRequest{Account: account})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return ledger.Statement{}, err
 	}
 
-	result := map[string]interface{}{
-		"found": resp.Found,
+	statement := ledger.Statement{
+		Account: ledger.Account(resp.Account),
+		Balance: resp.BalanceCents,
 	}
-
-	if resp.Found && resp.Product != nil {
-		result["product"] = map[string]interface{}{
-			"seller":        resp.Product.Seller,
-			"product":       resp.Product.Product,
-			"initial_price": resp.Product.InitialPrice,
-			"current_price": resp.Product.CurrentPrice,
-		}
+	for _, l := range resp.Lines {
+		statement.Lines = append(statement.Lines, ledger.Line{
+			EntryID: l.EntryId,
+			Time:    l.Time.AsTime(),
+			Kind:    ledger.Kind(l.Kind),
+			Product: l.Product,
+			Memo:    l.Memo,
+			Amount:  l.AmountCents,
+			Balance: l.BalanceCents,
+		})
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	return statement, nil
 }
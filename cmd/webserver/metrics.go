@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics exported on /metrics
+var (
+	httpSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "auction",
+		Name:      "http_request_seconds",
+		Help:      "Time taken to answer API requests, by path and HTTP status.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	}, []string{"path", "code"})
+
+	rpcSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "auction",
+		Name:      "grpc_client_handling_seconds",
+		Help:      "Time taken by gRPC calls to the auction server, by method and status code.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	}, []string{"method", "code"})
+)
+
+// registerMetrics registers the web server's metrics
+func registerMetrics() {
+	prometheus.MustRegister(httpSeconds, rpcSeconds)
+}
+
+// statusRecorder remembers the status a handler answered with
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush sends what was written on to the client, for streaming responses
+func (r *statusRecorder) Flush() {
+	http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController and WebSocket upgrades reach the
+// connection's own writer, which can flush and be hijacked
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// instrumented traces and times an API route, continuing any trace the
+// caller started. Only registered routes are wrapped, so the path label and
+// span names stay bounded.
+func instrumented(next http.HandlerFunc) http.HandlerFunc {
+	timed := func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(rec, r)
+		httpSeconds.WithLabelValues(r.URL.Path, strconv.Itoa(rec.code)).Observe(time.Since(start).Seconds())
+	}
+	traced := otelhttp.NewHandler(http.HandlerFunc(timed), "",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}))
+	return traced.ServeHTTP
+}
+
+// metricsClientInterceptor times calls to the auction server
+func metricsClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	rpcSeconds.WithLabelValues(path.Base(method), status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return err
+}
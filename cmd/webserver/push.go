@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// heartbeatInterval is how often an idle push connection is written to,
+	// so proxies keep it open and dead clients are noticed
+	heartbeatInterval = 15 * time.Second
+
+	// pushBuffer is how many events may wait for a slow client before the
+	// relay stops reading from the auction server
+	pushBuffer = 64
+
+	// pushWriteTimeout is how long a client may take to accept a write
+	// before it is disconnected
+	pushWriteTimeout = 10 * time.Second
+
+	// sseRetry is how long an EventSource waits before reconnecting
+	sseRetry = 3 * time.Second
+)
+
+// Types of pushed events
+const (
+	pushAuction      = "auction"
+	pushNotification = "notification"
+	// pushReset tells a client the events it missed are no longer kept, so
+	// it must reload the catalog
+	pushReset = "reset"
+)
+
+// pushes is cancelled when the web server shuts down, ending the push
+// connections and streaming calls, which Shutdown would otherwise wait for
+var pushes, stopPushes = context.WithCancel(context.Background())
+
+// pushEvent is an event pushed to a browser
+type pushEvent struct {
+	// ID is the sequence number of an auction event, which a reconnecting
+	// client sends back to resume after it
+	ID   string          `json:"id,omitempty"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// pushContext returns the context of the calls streaming events for r,
+// which ends with the request or when the server shuts down
+func pushContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return untilShutdown(outgoing(r.Context(), r))
+}
+
+// untilShutdown returns ctx, also cancelled when the server shuts down
+func untilShutdown(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(pushes, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// lastEventID returns the sequence number of the last auction event a
+// client received: the Last-Event-ID header an EventSource sends when it
+// reconnects, or ?last_event_id= for clients reconnecting themselves
+func lastEventID(r *http.Request) (uint64, error) {
+	id := r.Header.Get("Last-Event-ID")
+	if id == "" {
+		id = r.URL.Query().Get("last_event_id")
+	}
+	if id == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(id, 10, 64)
+}
+
+// subscribed waits for the headers the auction server sends once it
+// subscribed a stream. A stream without them ended before it started, and
+// receiving from it reports why.
+func subscribed(stream grpc.ClientStream) error {
+	if header, _ := stream.Header(); header != nil {
+		return nil
+	}
+	if err := stream.RecvMsg(&emptypb.Empty{}); err != nil && err != io.EOF {
+		return err
+	}
+	return status.Error(codes.Unavailable, "stream ended before it started")
+}
+
+// openFeed subscribes to the auction events after sequence number after.
+// If those are no longer kept it subscribes to the events from now on
+// instead, and reports that the client must reset.
+func openFeed(ctx context.Context, after uint64) (stream pb.AuctionService_SubscribeAuctionEventsClient, reset bool, err error) {
+	open := func(after uint64) (pb.AuctionService_SubscribeAuctionEventsClient, error) {
+		stream, err := grpcClient.SubscribeAuctionEvents(ctx, &pb.SubscribeAuctionEventsRequest{AfterSeq: after})
+		if err != nil {
+			return nil, err
+		}
+		return stream, subscribed(stream)
+	}
+
+	stream, err = open(after)
+	if status.Code(err) == codes.OutOfRange {
+		stream, err = open(0)
+		reset = true
+	}
+	return stream, reset, err
+}
+
+// send passes an event on, waiting for room as long as ctx allows
+func send(ctx context.Context, out chan<- pushEvent, ev pushEvent) error {
+	select {
+	case out <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// resetEvent tells the client to reload the catalog
+var resetEvent = pushEvent{Type: pushReset, Data: json.RawMessage("{}")}
+
+// relayAuctionEvents passes on the auction events of stream, which
+// starts after sequence number after. When the client falls so far behind
+// that the auction server ends the stream, it subscribes again after the
+// last event passed on.
+func relayAuctionEvents(ctx context.Context, stream pb.AuctionService_SubscribeAuctionEventsClient, after uint64, out chan<- pushEvent) error {
+	last := after
+	for {
+		ev, err := stream.Recv()
+		if status.Code(err) == codes.ResourceExhausted {
+			var reset bool
+			if stream, reset, err = openFeed(ctx, last); err != nil {
+				return err
+			}
+			if reset {
+				if err := send(ctx, out, resetEvent); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		data, err := marshalJSON.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		last = ev.GetSeq()
+		if err := send(ctx, out, pushEvent{ID: strconv.FormatUint(last, 10), Type: pushAuction, Data: data}); err != nil {
+			return err
+		}
+	}
+}
+
+// relayNotifications passes the notifications of stream on
+func relayNotifications(ctx context.Context, stream pb.AuctionService_SubscribeNotificationsClient, out chan<- pushEvent) error {
+	for {
+		n, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		data, err := marshalJSON.Marshal(n)
+		if err != nil {
+			return err
+		}
+		if err := send(ctx, out, pushEvent{Type: pushNotification, Data: data}); err != nil {
+			return err
+		}
+	}
+}
+
+// subscribe streams the auction events after sequence number after and,
+// if user is given, the user's notifications. The streams are open once it
+// returns, so a client learns of a failure to subscribe before any event.
+// The channel is closed once ctx is done or either stream ends; a client
+// too slow to keep up holds the relays back rather than being buffered for.
+func subscribe(ctx context.Context, user string, after uint64) (<-chan pushEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	feed, reset, err := openFeed(ctx, after)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	var notes pb.AuctionService_SubscribeNotificationsClient
+	if user != "" {
+		notes, err = grpcClient.SubscribeNotifications(ctx, &pb.SubscribeNotificationsRequest{User: user})
+		if err == nil {
+			err = subscribed(notes)
+		}
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	out := make(chan pushEvent, pushBuffer)
+	if reset {
+		out <- resetEvent
+	}
+
+	var wg sync.WaitGroup
+	relay := func(name string, run func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer cancel()
+			if err := run(); ctx.Err() == nil {
+				slog.InfoContext(ctx, "Event stream ended", "stream", name, "error", err)
+			}
+		}()
+	}
+	relay("auction", func() error { return relayAuctionEvents(ctx, feed, after, out) })
+	if notes != nil {
+		relay("notifications", func() error { return relayNotifications(ctx, notes, out) })
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out, nil
+}
+
+// sseMessage formats an event in the text/event-stream format
+func sseMessage(ev pushEvent) string {
+	var b strings.Builder
+	if ev.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", ev.ID)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", ev.Type, ev.Data)
+	return b.String()
+}
+
+// handleEvents streams live auction events as Server-Sent Events, e.g.
+// GET /events?user=Alice, which adds Alice's notifications. Auction events
+// carry their sequence number as the event ID, so an EventSource that
+// reconnects resumes after the last one it received.
+func handleEvents(w http.ResponseWriter, r *http.Request) {
+	after, err := lastEventID(r)
+	if err != nil {
+		http.Error(w, "invalid last event ID", http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := pushContext(r)
+	defer cancel()
+
+	events, err := subscribe(ctx, r.URL.Query().Get("user"), after)
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Keeps proxies such as nginx from holding events back
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	rc := http.NewResponseController(w)
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	msg := fmt.Sprintf("retry: %d\n\n", sseRetry.Milliseconds())
+	for {
+		rc.SetWriteDeadline(time.Now().Add(pushWriteTimeout))
+		if _, err := io.WriteString(w, msg); err != nil {
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			msg = ": heartbeat\n\n"
+		case ev, ok := <-events:
+			if !ok {
+				// The browser reconnects, resuming after the last event
+				return
+			}
+			msg = sseMessage(ev)
+		}
+	}
+}
+
+// handleWebSocket streams the same events as handleEvents over a
+// WebSocket, as JSON messages with an id, type and data. Clients resume
+// with ?last_event_id= when they reconnect.
+func handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	after, err := lastEventID(r)
+	if err != nil {
+		http.Error(w, "invalid last event ID", http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := pushContext(r)
+	defer cancel()
+
+	events, err := subscribe(ctx, r.URL.Query().Get("user"), after)
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+
+	// Only pages served from this host may connect
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		// Accept has answered the request
+		return
+	}
+	defer conn.CloseNow()
+	// Clients only listen; reading handles their pongs and close messages,
+	// and ends ctx when they go away
+	ctx = conn.CloseRead(ctx)
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close(websocket.StatusGoingAway, "server shutting down")
+			return
+		case <-heartbeat.C:
+			pingCtx, cancel := context.WithTimeout(ctx, pushWriteTimeout)
+			err = conn.Ping(pingCtx)
+			cancel()
+		case ev, ok := <-events:
+			if !ok {
+				conn.Close(websocket.StatusTryAgainLater, "event stream ended, reconnect")
+				return
+			}
+			writeCtx, cancel := context.WithTimeout(ctx, pushWriteTimeout)
+			err = wsjson.Write(writeCtx, conn, ev)
+			cancel()
+		}
+		if err != nil {
+			slog.InfoContext(r.Context(), "WebSocket client dropped", "error", err)
+			return
+		}
+	}
+}
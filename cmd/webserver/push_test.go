@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// feedServer keeps auction events firstKept to lastSeq, as the auction
+// server's feed does, and streams event lastSeq+1 as the live one
+type feedServer struct {
+	pb.UnimplementedAuctionServiceServer
+	firstKept, lastSeq uint64
+}
+
+func (f feedServer) SubscribeAuctionEvents(req *pb.SubscribeAuctionEventsRequest, stream pb.AuctionService_SubscribeAuctionEventsServer) error {
+	after := req.GetAfterSeq()
+	if after != 0 && after+1 < f.firstKept {
+		return status.Errorf(codes.OutOfRange, "changes after %d are no longer kept", after)
+	}
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	if after == 0 {
+		after = f.lastSeq
+	}
+	for seq := after + 1; seq <= f.lastSeq+1; seq++ {
+		if err := stream.Send(&pb.AuctionEvent{Seq: seq, Type: "bid.placed", Product: "lamp"}); err != nil {
+			return err
+		}
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+// sseEvent is an event as read from a text/event-stream
+type sseEvent struct {
+	id, typ string
+}
+
+// readSSE reads n events from an event stream, skipping comments and the
+// retry message
+func readSSE(t *testing.T, r *bufio.Reader, n int) []sseEvent {
+	t.Helper()
+	var (
+		list []sseEvent
+		ev   sseEvent
+	)
+	for len(list) < n {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("after %v: %v", list, err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if ev.typ != "" {
+				list = append(list, ev)
+			}
+			ev = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			ev.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			ev.typ = strings.TrimPrefix(line, "event: ")
+		}
+	}
+	return list
+}
+
+// TestEventsResume checks that an EventSource reconnecting with
+// Last-Event-ID gets the events it missed, and one whose events are no
+// longer kept is told to reset before the live events
+func TestEventsResume(t *testing.T) {
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterAuctionServiceServer(grpcServer, feedServer{firstKept: 5, lastSeq: 7})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	grpcClient = pb.NewAuctionServiceClient(conn)
+	web := httptest.NewServer(http.HandlerFunc(handleEvents))
+	defer web.Close()
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	for _, tc := range []struct {
+		name        string
+		lastEventID string
+		want        []sseEvent
+	}{
+		{"new", "", []sseEvent{{"8", pushAuction}}},
+		{"resume", "5", []sseEvent{{"6", pushAuction}, {"7", pushAuction}, {"8", pushAuction}}},
+		{"resume oldest kept", "4", []sseEvent{{"5", pushAuction}, {"6", pushAuction}}},
+		{"gap", "2", []sseEvent{{"", pushReset}, {"8", pushAuction}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, web.URL, nil)
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if ct := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusOK || ct != "text/event-stream" {
+				t.Fatalf("GET /events: %s, %s", resp.Status, ct)
+			}
+
+			got := readSSE(t, bufio.NewReader(resp.Body), len(tc.want))
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("events = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	resp, err := client.Get(web.URL + "?last_event_id=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid last event ID: %s, want %d", resp.Status, http.StatusBadRequest)
+	}
+}
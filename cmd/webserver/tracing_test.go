@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"github.com/930r91na/Subasta-grpc/pkg/ratelimit"
+	"github.com/930r91na/Subasta-grpc/pkg/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// statementServer answers GetStatement with an empty statement
+type statementServer struct {
+	pb.UnimplementedAuctionServiceServer
+}
+
+func (statementServer) GetStatement(ctx context.Context, req *pb.GetStatementRequest) (*pb.GetStatementResponse, error) {
+	return &pb.GetStatementResponse{Account: req.GetAccount()}, nil
+}
+
+// spanNamed returns the single ended span of a kind whose name has prefix
+func spanNamed(t *testing.T, spans tracetest.SpanStubs, kind trace.SpanKind, prefix string) tracetest.SpanStub {
+	t.Helper()
+	var found []tracetest.SpanStub
+	for _, s := range spans {
+		if s.SpanKind == kind && strings.HasPrefix(s.Name, prefix) {
+			found = append(found, s)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("want one %s span named %s*, have %d among %d spans", kind, prefix, len(found), len(spans))
+	}
+	return found[0]
+}
+
+// TestTraceFlowsToAuctionServer checks that a request's trace continues
+// from the caller through the web server's span into the auction server's,
+// both through the API relayed from the proto and the handlers calling the
+// client directly
+func TestTraceFlowsToAuctionServer(t *testing.T) {
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.Install("auction-test", sdktrace.NewSimpleSpanProcessor(exporter))
+	defer provider.Shutdown(context.Background())
+
+	// The auction server, traced as cmd/server does
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	pb.RegisterAuctionServiceServer(grpcServer, statementServer{})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	// The web server's client and routes, set up as main does
+	conn, err := grpc.NewClient(lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(metricsClientInterceptor, loggingClientInterceptor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	grpcClient = pb.NewAuctionServiceClient(conn)
+	limits, err := ratelimit.ParseLimits("*=off")
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter = ratelimit.New(limits)
+	if err := registerGateway(conn, pb.File_auction_proto.Services().ByName("AuctionService")); err != nil {
+		t.Fatal(err)
+	}
+	http.HandleFunc("/ledger/statement.csv", instrumented(logged(corsMiddleware(rateLimited(handleExportStatement)))))
+	web := httptest.NewServer(http.DefaultServeMux)
+	defer web.Close()
+
+	for _, tc := range []struct {
+		name, method, path, body string
+	}{
+		{"gateway", http.MethodPost, "/auction.AuctionService/GetStatement", `{"account":"Alice"}`},
+		{"handler", http.MethodGet, "/ledger/statement.csv?account=Alice", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter.Reset()
+			const (
+				traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+				callerSpanID = "00f067aa0ba902b7"
+			)
+			req, _ := http.NewRequest(tc.method, web.URL+tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("traceparent", "00-"+traceID+"-"+callerSpanID+"-01")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("%s %s: %s", tc.method, tc.path, resp.Status)
+			}
+
+			spans := exporter.GetSpans()
+			web := spanNamed(t, spans, trace.SpanKindServer, tc.method+" ")
+			client := spanNamed(t, spans, trace.SpanKindClient, "auction.AuctionService/GetStatement")
+			server := spanNamed(t, spans, trace.SpanKindServer, "auction.AuctionService/GetStatement")
+
+			for _, s := range []tracetest.SpanStub{web, client, server} {
+				if got := s.SpanContext.TraceID().String(); got != traceID {
+					t.Errorf("span %s is in trace %s, want the caller's %s", s.Name, got, traceID)
+				}
+			}
+			if got := web.Parent.SpanID().String(); got != callerSpanID {
+				t.Errorf("web server span's parent = %s, want the caller's span %s", got, callerSpanID)
+			}
+			if client.Parent.SpanID() != web.SpanContext.SpanID() {
+				t.Errorf("call's parent = %s, want the web server's span %s", client.Parent.SpanID(), web.SpanContext.SpanID())
+			}
+			if server.Parent.SpanID() != client.SpanContext.SpanID() {
+				t.Errorf("auction server span's parent = %s, want the web server's call %s", server.Parent.SpanID(), client.SpanContext.SpanID())
+			}
+			if !server.Parent.IsRemote() {
+				t.Error("auction server span's parent did not arrive over the wire")
+			}
+		})
+	}
+}
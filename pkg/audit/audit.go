@@ -0,0 +1,64 @@
+// Package audit keeps a record of privileged actions in memory. It keeps
+// nothing itself: whoever owns the log rebuilds it from wherever the actions
+// were recorded, such as an event log.
+package audit
+
+import (
+	"sync"
+	"time"
+)
+
+// Entry records one action
+type Entry struct {
+	ID      int64     `json:"id"`
+	Time    time.Time `json:"time"`
+	Actor   string    `json:"actor"`
+	Action  string    `json:"action"`
+	Request string    `json:"request,omitempty"`
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// Log is an audit log
+type Log struct {
+	mu      sync.Mutex
+	entries []Entry
+	nextID  int64
+}
+
+// New creates an empty audit log
+func New() *Log {
+	return &Log{nextID: 1}
+}
+
+// Record appends an entry, assigning its ID and, if unset, its time
+func (l *Log) Record(e Entry) Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.ID = l.nextID
+	l.nextID++
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	l.entries = append(l.entries, e)
+	return e
+}
+
+// List returns up to limit entries, newest first. A limit of zero or less
+// returns every entry.
+func (l *Log) List(limit int) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := len(l.entries)
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	list := make([]Entry, 0, limit)
+	for i := n - 1; i >= n-limit; i-- {
+		list = append(list, l.entries[i])
+	}
+	return list
+}
@@ -0,0 +1,235 @@
+// Package certs secures the auction's connections with TLS. Certificates
+// and CA bundles are read from PEM files and re-read when the files change,
+// so they can be renewed without restarting anything.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// checkInterval is how often the files are checked for changes, at most
+// once per handshake
+const checkInterval = 5 * time.Second
+
+// Reloader holds a certificate and a CA bundle loaded from files, picking up
+// new versions of the files as they are written
+type Reloader struct {
+	certFile, keyFile, caFile string
+
+	mu      sync.Mutex
+	checked time.Time
+	modTime time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+// NewReloader loads the certificate in certFile and keyFile and the CA
+// certificates in caFile. Either the pair or caFile may be empty: a server
+// needs a certificate, a client that does not present one only the CA.
+func NewReloader(certFile, keyFile, caFile string) (*Reloader, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("certs: a certificate needs both its cert and key files")
+	}
+	r := &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.checked = time.Now()
+	return r, nil
+}
+
+// files lists the files the reloader reads
+func (r *Reloader) files() []string {
+	var files []string
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// latest returns when the files were last modified
+func (r *Reloader) latest() (time.Time, error) {
+	var latest time.Time
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// load reads the files, keeping what was loaded before if any is unreadable
+func (r *Reloader) load() error {
+	modTime, err := r.latest()
+	if err != nil {
+		return err
+	}
+
+	var cert *tls.Certificate
+	if r.certFile != "" {
+		pair, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return fmt.Errorf("certs: %s: %v", r.certFile, err)
+		}
+		cert = &pair
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		data, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("certs: %s: no certificates found", r.caFile)
+		}
+	}
+
+	r.cert, r.pool, r.modTime = cert, pool, modTime
+	return nil
+}
+
+// current returns the certificate and CA pool, reloading them first if the
+// files changed
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) >= checkInterval {
+		r.checked = time.Now()
+		if modTime, err := r.latest(); err != nil {
+			slog.Warn("Failed to check certificates, keeping the loaded ones", "files", r.files(), "error", err)
+		} else if modTime.After(r.modTime) {
+			if err := r.load(); err != nil {
+				slog.Warn("Failed to reload certificates, keeping the loaded ones", "files", r.files(), "error", err)
+			} else {
+				slog.Info("Reloaded certificates", "files", r.files())
+			}
+		}
+	}
+	return r.cert, r.pool
+}
+
+// ServerConfig returns a TLS configuration serving the certificate and
+// verifying client certificates against the CA as clientAuth asks. protos
+// are the application protocols offered, e.g. "h2" for gRPC.
+func (r *Reloader) ServerConfig(clientAuth tls.ClientAuthType, protos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Each handshake gets the certificates current at the time
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := r.current()
+			if cert == nil {
+				return nil, errors.New("certs: no server certificate")
+			}
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				ClientCAs:    pool,
+				ClientAuth:   clientAuth,
+				NextProtos:   protos,
+			}, nil
+		},
+	}
+}
+
+// ClientConfig returns a TLS configuration verifying servers against the CA,
+// or the system's CAs if there is no CA file, and presenting the
+// certificate, if any, to servers that ask for one
+func (r *Reloader) ClientConfig() *tls.Config {
+	if r.caFile == "" {
+		return &tls.Config{
+			MinVersion:           tls.VersionTLS12,
+			GetClientCertificate: r.clientCertificate,
+		}
+	}
+	return &tls.Config{
+		MinVersion:           tls.VersionTLS12,
+		GetClientCertificate: r.clientCertificate,
+		// The chain is verified in VerifyConnection instead, against the CA
+		// as it is at the time
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			_, pool := r.current()
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("certs: server sent no certificate")
+			}
+			opts := x509.VerifyOptions{
+				Roots:         pool,
+				DNSName:       cs.ServerName,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, c := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(c)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
+
+// clientCertificate returns the certificate to present to a server, which
+// is empty when there is none
+func (r *Reloader) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if cert, _ := r.current(); cert != nil {
+		return cert, nil
+	}
+	return &tls.Certificate{}, nil
+}
+
+// ParseClientAuth parses how a server treats client certificates: "none"
+// ignores them, "request" verifies those sent, "require" rejects clients
+// without one
+func ParseClientAuth(s string) (tls.ClientAuthType, error) {
+	switch s {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require":
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return tls.NoClientCert, fmt.Errorf("client auth %q is not none, request or require", s)
+}
+
+// ClientCredentials returns the credentials to dial a gRPC server with:
+// plaintext when caFile is empty, TLS verified against caFile otherwise,
+// presenting the certificate in certFile and keyFile if given
+func ClientCredentials(caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	if caFile == "" {
+		if certFile != "" {
+			return nil, errors.New("certs: a client certificate needs the CA to verify the server with")
+		}
+		return insecure.NewCredentials(), nil
+	}
+	r, err := NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(r.ClientConfig()), nil
+}
+
+// PeerName returns the name a verified certificate was issued to, its
+// common name, or "" if the chain was not verified
+func PeerName(state tls.ConnectionState) string {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return state.VerifiedChains[0][0].Subject.CommonName
+}
@@ -0,0 +1,390 @@
+// Package cluster replicates the auction's event log across several servers
+// with Raft. The leader commits every event through the Raft log before it
+// is kept, so an acknowledged bid survives the loss of any minority of
+// nodes; followers fold committed events into their own state and hand
+// writes to the leader.
+package cluster
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/events"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+)
+
+// ErrNotLeader is returned when appending events on a node that is not the
+// leader, or has not yet caught up since becoming leader
+var ErrNotLeader = errors.New("cluster: not the leader")
+
+// barrierTimeout bounds how long a new leader waits to start catching up
+const barrierTimeout = 5 * time.Second
+
+// Peer is one server of the cluster
+type Peer struct {
+	ID string
+	// Addr is the address the peer serves gRPC on
+	Addr string
+	// RaftAddr is the address the peer replicates on
+	RaftAddr string
+}
+
+// ParsePeers parses peers written as "id=addr/raft-addr,...", e.g.
+// "n1=127.0.0.1:50051/127.0.0.1:7051"
+func ParsePeers(s string) ([]Peer, error) {
+	var peers []Peer
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, addrs, ok := strings.Cut(item, "=")
+		addr, raftAddr, ok2 := strings.Cut(addrs, "/")
+		if !ok || !ok2 || id == "" || addr == "" || raftAddr == "" {
+			return nil, fmt.Errorf("peer %q: expected id=addr/raft-addr", item)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("peer %s listed twice", id)
+		}
+		seen[id] = true
+		peers = append(peers, Peer{ID: id, Addr: addr, RaftAddr: raftAddr})
+	}
+	if len(peers) == 0 {
+		return nil, errors.New("no peers")
+	}
+	return peers, nil
+}
+
+// Config describes this node and its cluster
+type Config struct {
+	// NodeID is this node's ID among Peers
+	NodeID string
+	Peers  []Peer
+	// Dir holds the Raft log and snapshots
+	Dir string
+	// ServerTLS and ClientTLS, if set, secure replication: nodes accept
+	// connections with ServerTLS and dial each other with ClientTLS. Each
+	// node's certificate is issued to its ID.
+	ServerTLS, ClientTLS *tls.Config
+}
+
+// Node is this server's member of the Raft group
+type Node struct {
+	raft  *raft.Raft
+	self  Peer
+	peers map[string]Peer
+	fsm   *fsm
+	// ready is set once this node leads and has applied every earlier entry
+	ready atomic.Bool
+	// proposals are numbered per start of the node
+	start int64
+	seq   atomic.Uint64
+	done  chan struct{}
+}
+
+// Open joins the cluster, replicating eventLog. Events committed by other
+// nodes are recorded in eventLog and then passed to apply, which folds them into the
+// server's live state; events appended on this node are applied by the
+// caller as usual. The first start of every peer bootstraps the cluster
+// from cfg.Peers.
+func Open(cfg Config, eventLog *events.Log, apply func(events.Event)) (*Node, error) {
+	n := &Node{
+		peers: make(map[string]Peer),
+		fsm:   &fsm{log: eventLog, apply: apply, pending: make(map[string]bool)},
+		start: time.Now().UnixNano(),
+		done:  make(chan struct{}),
+	}
+	for _, p := range cfg.Peers {
+		n.peers[p.ID] = p
+	}
+	self, ok := n.peers[cfg.NodeID]
+	if !ok {
+		return nil, fmt.Errorf("cluster: node %q is not one of the peers", cfg.NodeID)
+	}
+	n.self = self
+
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	store, err := raftboltdb.NewBoltStore(filepath.Join(cfg.Dir, "raft.db"))
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := raft.NewFileSnapshotStore(cfg.Dir, 2, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := newTransport(self.RaftAddr, cfg.ServerTLS, cfg.ClientTLS, n.peers)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := raft.DefaultConfig()
+	conf.LocalID = raft.ServerID(self.ID)
+	conf.Logger = hclog.New(&hclog.LoggerOptions{
+		Name:       "raft",
+		Level:      hclog.Warn,
+		Output:     os.Stderr,
+		JSONFormat: true,
+	})
+
+	existing, err := raft.HasExistingState(store, store, snapshots)
+	if err != nil {
+		return nil, err
+	}
+	r, err := raft.NewRaft(conf, n.fsm, store, store, snapshots, transport)
+	if err != nil {
+		return nil, err
+	}
+	n.raft = r
+
+	if !existing {
+		var servers []raft.Server
+		for _, p := range cfg.Peers {
+			servers = append(servers, raft.Server{ID: raft.ServerID(p.ID), Address: raft.ServerAddress(p.RaftAddr)})
+		}
+		// Every peer bootstraps with the same configuration, so whichever
+		// starts first wins and the others' attempts are harmless
+		if err := r.BootstrapCluster(raft.Configuration{Servers: servers}).Error(); err != nil && !errors.Is(err, raft.ErrCantBootstrap) {
+			return nil, err
+		}
+	}
+
+	go n.watchLeadership()
+	return n, nil
+}
+
+// watchLeadership marks the node ready to append events once it leads and
+// has applied everything committed before its term
+func (n *Node) watchLeadership() {
+	for {
+		select {
+		case <-n.done:
+			return
+		case leader := <-n.raft.LeaderCh():
+			n.ready.Store(false)
+			if !leader {
+				slog.Info("Following the leader", "node", n.self.ID)
+				continue
+			}
+			if n.catchUp() {
+				slog.Info("Leading the cluster", "node", n.self.ID)
+			}
+		}
+	}
+}
+
+// catchUp waits until every committed entry has been applied here and then
+// lets the leader append events again
+func (n *Node) catchUp() bool {
+	if err := n.raft.Barrier(barrierTimeout).Error(); err != nil {
+		slog.Warn("Failed to catch up as leader", "node", n.self.ID, "error", err)
+		return false
+	}
+	n.ready.Store(n.raft.State() == raft.Leader)
+	return n.ready.Load()
+}
+
+// IsLeader reports whether this node accepts writes
+func (n *Node) IsLeader() bool {
+	return n.ready.Load() && n.raft.State() == raft.Leader
+}
+
+// Leader returns the current leader, or false if none is known
+func (n *Node) Leader() (Peer, bool) {
+	_, id := n.raft.LeaderWithID()
+	p, ok := n.peers[string(id)]
+	return p, ok
+}
+
+// Self returns this node
+func (n *Node) Self() Peer {
+	return n.self
+}
+
+// Propose starts committing an event appended on this node through the
+// Raft log. It implements events.Replicator.
+func (n *Node) Propose(e events.Event) (func() error, error) {
+	if !n.IsLeader() {
+		return nil, ErrNotLeader
+	}
+
+	id := fmt.Sprintf("%s-%d-%d", n.self.ID, n.start, n.seq.Add(1))
+	data, err := json.Marshal(entry{ID: id, Event: e})
+	if err != nil {
+		return nil, err
+	}
+
+	n.fsm.propose(id)
+	// Without a timeout the entry is either queued behind those proposed
+	// before it or refused with them, so the sequence numbers committed
+	// never skip one
+	future := n.raft.Apply(data, 0)
+	return func() error {
+		err := future.Error()
+		if !n.fsm.withdraw(id) {
+			// Applied here, so it is committed whatever the future says
+			if err, ok := future.Response().(error); ok {
+				return err
+			}
+			return nil
+		}
+		if errors.Is(err, raft.ErrNotLeader) || errors.Is(err, raft.ErrLeadershipLost) {
+			return ErrNotLeader
+		}
+
+		// The entry may still commit, under the sequence number the log
+		// is about to hand out again. Stop appending until it has been
+		// applied like any other node's event.
+		n.ready.Store(false)
+		go n.catchUp()
+		if err == nil {
+			err = ErrNotLeader
+		}
+		return err
+	}, nil
+}
+
+// Close leaves the cluster, stopping replication on this node. A leader
+// hands over first, so the others need not wait out an election timeout.
+func (n *Node) Close() error {
+	close(n.done)
+	if n.raft.State() == raft.Leader {
+		if err := n.raft.LeadershipTransfer().Error(); err != nil {
+			slog.Warn("Failed to hand over leadership", "node", n.self.ID, "error", err)
+		}
+	}
+	return n.raft.Shutdown().Error()
+}
+
+// entry is the Raft log form of an event
+type entry struct {
+	// ID identifies the proposal, so the node that made it knows its own
+	ID    string       `json:"id"`
+	Event events.Event `json:"event"`
+}
+
+// fsm folds committed events into the node's log and state
+type fsm struct {
+	log   *events.Log
+	apply func(events.Event)
+
+	mu sync.Mutex
+	// pending are the proposals of this node awaiting commit; their events
+	// are kept here like any other, but folded into the state by the
+	// caller of Log.Append
+	pending map[string]bool
+	// applied is the sequence number of the latest committed event
+	applied atomic.Uint64
+}
+
+func (f *fsm) propose(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending[id] = true
+}
+
+// withdraw forgets a proposal, reporting whether it was still pending
+func (f *fsm) withdraw(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pending := f.pending[id]
+	delete(f.pending, id)
+	return pending
+}
+
+// Apply implements raft.FSM
+func (f *fsm) Apply(l *raft.Log) interface{} {
+	if l.Type != raft.LogCommand {
+		return nil
+	}
+	var en entry
+	if err := json.Unmarshal(l.Data, &en); err != nil {
+		slog.Error("Skipping unreadable raft entry", "index", l.Index, "error", err)
+		return err
+	}
+	f.applied.Store(en.Event.Seq)
+
+	f.mu.Lock()
+	mine := f.pending[en.ID]
+	delete(f.pending, en.ID)
+	f.mu.Unlock()
+	return f.receive(en.Event, !mine)
+}
+
+// receive records a committed event, folding it into the state if it was
+// appended elsewhere
+func (f *fsm) receive(e events.Event, fold bool) error {
+	fresh, err := f.log.Receive(e)
+	if err != nil {
+		slog.Error("Failed to record replicated event", "seq", e.Seq, "error", err)
+		return err
+	}
+	if fresh && fold {
+		f.apply(e)
+	}
+	return nil
+}
+
+// Snapshot implements raft.FSM. Every event is in the node's own log, so
+// the snapshot only notes how far it goes; the events are read when it is
+// persisted.
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	return &snapshot{log: f.log, seq: f.applied.Load()}, nil
+}
+
+// Restore implements raft.FSM, catching up on the events of a snapshot
+// sent by the leader
+func (f *fsm) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+
+	dec := json.NewDecoder(rc)
+	for dec.More() {
+		var e events.Event
+		if err := dec.Decode(&e); err != nil {
+			return err
+		}
+		if err := f.receive(e, true); err != nil {
+			return err
+		}
+		f.applied.Store(e.Seq)
+	}
+	return nil
+}
+
+// snapshot is the event log up to a sequence number
+type snapshot struct {
+	log *events.Log
+	seq uint64
+}
+
+// Persist implements raft.FSMSnapshot, writing the events as JSON lines
+func (s *snapshot) Persist(sink raft.SnapshotSink) error {
+	enc := json.NewEncoder(sink)
+	for _, e := range s.log.Events() {
+		if e.Seq > s.seq {
+			break
+		}
+		if err := enc.Encode(e); err != nil {
+			sink.Cancel()
+			return err
+		}
+	}
+	return sink.Close()
+}
+
+// Release implements raft.FSMSnapshot
+func (s *snapshot) Release() {}
@@ -0,0 +1,91 @@
+package cluster
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+	"github.com/hashicorp/raft"
+)
+
+// newTransport listens for Raft traffic on addr, in plaintext unless the
+// TLS configurations are set. Over TLS, only certificates issued to one of
+// peers are accepted on either end.
+func newTransport(addr string, serverTLS, clientTLS *tls.Config, peers map[string]Peer) (raft.Transport, error) {
+	advertise, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if serverTLS == nil {
+		return raft.NewTCPTransport(addr, advertise, 3, 10*time.Second, os.Stderr)
+	}
+	if clientTLS == nil {
+		return nil, errors.New("cluster: TLS replication needs both server and client configurations")
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	layer := &tlsStreamLayer{
+		Listener:  tls.NewListener(ln, peersOnly(serverTLS, peers)),
+		advertise: advertise,
+		config:    peersOnly(clientTLS, peers),
+	}
+	return raft.NewNetworkTransport(layer, 3, 10*time.Second, os.Stderr), nil
+}
+
+// peersOnly returns config rejecting connections whose certificate was not
+// issued to one of peers, after config's own checks. The cluster's CA may
+// also issue users' certificates, which must not reach Raft.
+func peersOnly(config *tls.Config, peers map[string]Peer) *tls.Config {
+	config = config.Clone()
+	if get := config.GetConfigForClient; get != nil {
+		config.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			c, err := get(hello)
+			if c == nil || err != nil {
+				return c, err
+			}
+			return peersOnly(c, peers), nil
+		}
+	}
+	verify := config.VerifyConnection
+	config.VerifyConnection = func(cs tls.ConnectionState) error {
+		if verify != nil {
+			if err := verify(cs); err != nil {
+				return err
+			}
+		}
+		name := certs.PeerName(cs)
+		// A client verifying the chain itself has no verified chains
+		if name == "" && verify != nil && len(cs.PeerCertificates) > 0 {
+			name = cs.PeerCertificates[0].Subject.CommonName
+		}
+		if _, ok := peers[name]; !ok {
+			return fmt.Errorf("cluster: certificate of %q is not a peer's", name)
+		}
+		return nil
+	}
+	return config
+}
+
+// tlsStreamLayer carries Raft traffic over TLS
+type tlsStreamLayer struct {
+	net.Listener
+	advertise net.Addr
+	config    *tls.Config
+}
+
+// Dial connects to another node
+func (l *tlsStreamLayer) Dial(addr raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	return tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", string(addr), l.config)
+}
+
+// Addr returns the address other nodes reach this one at
+func (l *tlsStreamLayer) Addr() net.Addr {
+	return l.advertise
+}
@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"crypto/tls"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/certs"
+)
+
+// TestTransportRefusesUsers checks that Raft over TLS only connects nodes
+// with certificates issued to peers, though users' come from the same CA
+func TestTransportRefusesUsers(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := certs.NewCA("test CA", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ca.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]*certs.Reloader)
+	for _, name := range []string{"n1", "n2", "John"} {
+		if err := ca.IssueFiles(dir, name, []string{"127.0.0.1"}, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		r, err := certs.NewReloader(filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem"), filepath.Join(dir, "ca.pem"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = r
+	}
+	peers := map[string]Peer{"n1": {ID: "n1"}, "n2": {ID: "n2"}}
+
+	// listen serves a handshake with the named certificate, reporting
+	// whether it succeeded
+	listen := func(name string, check bool) (addr string, handshakes chan error) {
+		config := files[name].ServerConfig(tls.RequireAndVerifyClientCert)
+		if check {
+			config = peersOnly(config, peers)
+		}
+		lis, err := tls.Listen("tcp", "127.0.0.1:0", config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { lis.Close() })
+		handshakes = make(chan error, 1)
+		go func() {
+			conn, err := lis.Accept()
+			if err != nil {
+				handshakes <- err
+				return
+			}
+			defer conn.Close()
+			err = conn.(*tls.Conn).Handshake()
+			if err == nil {
+				_, err = conn.Write([]byte{1})
+			}
+			handshakes <- err
+		}()
+		return lis.Addr().String(), handshakes
+	}
+	// dial connects with the named certificate and reads a byte
+	dial := func(addr, name string, check bool) error {
+		config := files[name].ClientConfig()
+		if check {
+			config = peersOnly(config, peers)
+		}
+		conn, err := tls.Dial("tcp", addr, config)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		return err
+	}
+
+	for _, tc := range []struct {
+		name           string
+		server, client string
+		serverChecks   bool
+		ok             bool
+	}{
+		{"peers", "n1", "n2", true, true},
+		{"user dials a node", "n1", "John", true, false},
+		{"node dials a user", "John", "n2", false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, handshakes := listen(tc.server, tc.serverChecks)
+			err := dial(addr, tc.client, tc.client != "John")
+			serverErr := <-handshakes
+			if tc.ok && (err != nil || serverErr != nil) {
+				t.Fatalf("connection refused: client %v, server %v", err, serverErr)
+			}
+			if !tc.ok && err == nil {
+				t.Fatal("connection accepted")
+			}
+		})
+	}
+}
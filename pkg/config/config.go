@@ -0,0 +1,300 @@
+// Package config loads the settings of the auction's binaries. Settings are
+// the fields of a struct, grouped into sections by nested structs and named
+// by their yaml tags. Each is taken, from lowest to highest precedence, from
+// the struct's defaults, a YAML file, an environment variable and a command
+// line flag.
+//
+// Tags on a field:
+//
+//	yaml:"addr"       the setting's key within its section
+//	flag:"addr"       the command line flag setting it, if any
+//	env:"NAME"        the environment variable setting it, instead of the
+//	                  one derived from the prefix and key, e.g.
+//	                  AUCTION_SERVER_NETWORK_ADDR
+//	usage:"..."       the flag's help text
+//	secret:"true"     hidden by Print
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validator is implemented by settings that check themselves once loaded
+type Validator interface {
+	Validate() error
+}
+
+// setting is one field of the settings struct
+type setting struct {
+	key    string
+	flag   string
+	env    string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses s into the setting's field
+func (s *setting) set(str string) error {
+	v, err := parse(s.value.Type(), str)
+	if err != nil {
+		return err
+	}
+	s.value.Set(v)
+	return nil
+}
+
+// parse reads a value of type t from its command line form
+func parse(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch {
+	case t == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(int64(d))
+	case t.Kind() == reflect.String:
+		v.SetString(s)
+	case t.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case t.Kind() == reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(int64(n))
+	case t.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(f)
+	default:
+		return v, fmt.Errorf("unsupported setting type %s", t)
+	}
+	return v, nil
+}
+
+// settings lists the fields of the struct v points to
+func settings(v reflect.Value, prefix string) []*setting {
+	var all []*setting
+	var walk func(v reflect.Value, path []string)
+	walk = func(v reflect.Value, path []string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(f.Name)
+			}
+			keys := append(append([]string(nil), path...), name)
+			if f.Type.Kind() == reflect.Struct && f.Type != durationType {
+				walk(v.Field(i), keys)
+				continue
+			}
+
+			s := &setting{
+				key:    strings.Join(keys, "."),
+				flag:   f.Tag.Get("flag"),
+				env:    f.Tag.Get("env"),
+				usage:  f.Tag.Get("usage"),
+				secret: f.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			}
+			if s.env == "" {
+				s.env = prefix + strings.ToUpper(strings.Join(keys, "_"))
+			}
+			all = append(all, s)
+		}
+	}
+	walk(v, nil)
+	return all
+}
+
+// bind registers the flag of a setting, whose value lands in a scratch
+// variable so it can be applied after the file and environment are read
+func (s *setting) bind(fs *flag.FlagSet) (reflect.Value, error) {
+	scratch := reflect.New(s.value.Type())
+	scratch.Elem().Set(s.value)
+	usage := fmt.Sprintf("%s ($%s)", s.usage, s.env)
+	switch p := scratch.Interface().(type) {
+	case *time.Duration:
+		fs.DurationVar(p, s.flag, *p, usage)
+	case *string:
+		fs.StringVar(p, s.flag, *p, usage)
+	case *bool:
+		fs.BoolVar(p, s.flag, *p, usage)
+	case *int:
+		fs.IntVar(p, s.flag, *p, usage)
+	case *float64:
+		fs.Float64Var(p, s.flag, *p, usage)
+	default:
+		return scratch, fmt.Errorf("config: setting %s has unsupported type %s", s.key, s.value.Type())
+	}
+	return scratch.Elem(), nil
+}
+
+// Load fills cfg, a pointer to a struct holding the defaults, from the
+// command line args. A -config flag, or the envPrefix+"CONFIG" variable,
+// names a YAML file to read first; environment variables are named after
+// envPrefix, e.g. "AUCTION_SERVER_". Load reports whether -print-config was
+// given, in which case the caller should Print the settings and exit. It
+// returns flag.ErrHelp for -h.
+func Load(cfg any, name, envPrefix string, args []string) (printConfig bool, err error) {
+	printConfig, rest, err := load(cfg, name, envPrefix, "", args)
+	if err == nil && len(rest) > 0 {
+		err = fmt.Errorf("unexpected arguments %q", rest)
+	}
+	return printConfig, err
+}
+
+// LoadCommand is Load for binaries taking arguments after their flags, such
+// as a command to run, which it returns. usage, if set, is printed before
+// the flags for -h.
+func LoadCommand(cfg any, name, envPrefix, usage string, args []string) (printConfig bool, rest []string, err error) {
+	return load(cfg, name, envPrefix, usage, args)
+}
+
+// load fills cfg as Load does and returns the arguments after the flags
+func load(cfg any, name, envPrefix, usage string, args []string) (printConfig bool, rest []string, err error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return false, nil, fmt.Errorf("config: %T is not a pointer to a struct", cfg)
+	}
+	all := settings(v.Elem(), envPrefix)
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if usage != "" {
+		fs.Usage = func() {
+			fmt.Fprint(fs.Output(), usage)
+			fs.PrintDefaults()
+		}
+	}
+	configFile := fs.String("config", os.Getenv(envPrefix+"CONFIG"),
+		fmt.Sprintf("YAML file of settings, overridden by environment variables and flags ($%sCONFIG)", envPrefix))
+	fs.BoolVar(&printConfig, "print-config", false, "print the settings in effect as YAML and exit")
+	flags := make(map[string]reflect.Value)
+	bound := make(map[string]*setting)
+	for _, s := range all {
+		if s.flag == "" {
+			continue
+		}
+		scratch, err := s.bind(fs)
+		if err != nil {
+			return false, nil, err
+		}
+		flags[s.flag], bound[s.flag] = scratch, s
+	}
+	if err := fs.Parse(args); err != nil {
+		return false, nil, err
+	}
+	rest = fs.Args()
+
+	if *configFile != "" {
+		if err := readFile(cfg, *configFile); err != nil {
+			return false, rest, err
+		}
+	}
+	for _, s := range all {
+		if str, ok := os.LookupEnv(s.env); ok {
+			if err := s.set(str); err != nil {
+				return false, rest, fmt.Errorf("$%s: %v", s.env, err)
+			}
+		}
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if s, ok := bound[f.Name]; ok {
+			s.value.Set(flags[f.Name])
+		}
+	})
+
+	if validator, ok := cfg.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return printConfig, rest, err
+		}
+	}
+	return printConfig, rest, nil
+}
+
+// readFile reads settings from a YAML file, rejecting unknown keys
+func readFile(cfg any, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+// Print writes the settings as YAML, hiding secrets
+func Print(w io.Writer, cfg any) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	shown := reflect.New(v.Type())
+	shown.Elem().Set(v)
+	for _, s := range settings(shown.Elem(), "") {
+		if s.secret && !s.value.IsZero() {
+			s.value.SetString("<redacted>")
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(shown.Interface()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Errors gathers the problems found validating settings
+type Errors []error
+
+// Addf records a problem with the setting key
+func (e *Errors) Addf(key, format string, args ...any) {
+	*e = append(*e, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+// Err returns the problems as one error, or nil if there were none
+func (e Errors) Err() error {
+	return errors.Join(e...)
+}
+
+// CheckAddr checks that addr is a host:port address to listen on or dial
+func CheckAddr(addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if _, err := net.LookupPort("tcp", port); err != nil {
+		return err
+	}
+	return nil
+}
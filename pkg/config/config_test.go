@@ -0,0 +1,148 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testConfig has a setting of each kind the tests need
+type testConfig struct {
+	Network struct {
+		Addr    string        `yaml:"addr" flag:"addr" usage:"address"`
+		Timeout time.Duration `yaml:"timeout" flag:"timeout" usage:"timeout"`
+	} `yaml:"network"`
+	Workers  int    `yaml:"workers" flag:"workers" usage:"workers"`
+	Password string `yaml:"password" flag:"password" env:"SECRET_PASSWORD" secret:"true" usage:"password"`
+	Unset    string `yaml:"unset" secret:"true"`
+}
+
+func defaults() *testConfig {
+	cfg := &testConfig{}
+	cfg.Network.Addr = "default:1"
+	cfg.Network.Timeout = time.Second
+	cfg.Workers = 1
+	return cfg
+}
+
+// writeFile writes a YAML file of settings and returns its path
+func writeFile(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPrecedence(t *testing.T) {
+	file := writeFile(t, "network:\n  addr: file:1\n  timeout: 2s\nworkers: 2\n")
+	env := map[string]string{"TEST_NETWORK_ADDR": "env:1", "TEST_WORKERS": "3"}
+	for _, tc := range []struct {
+		name    string
+		env     map[string]string
+		args    []string
+		addr    string
+		timeout time.Duration
+		workers int
+	}{
+		{"defaults", nil, nil, "default:1", time.Second, 1},
+		{"file", nil, []string{"-config", file}, "file:1", 2 * time.Second, 2},
+		{"file named by the environment", map[string]string{"TEST_CONFIG": file}, nil, "file:1", 2 * time.Second, 2},
+		{"environment over file", env, []string{"-config", file}, "env:1", 2 * time.Second, 3},
+		{"flag over environment", env, []string{"-config", file, "-addr", "flag:1"}, "flag:1", 2 * time.Second, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			cfg := defaults()
+			if _, err := Load(cfg, "test", "TEST_", tc.args); err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Network.Addr != tc.addr || cfg.Network.Timeout != tc.timeout || cfg.Workers != tc.workers {
+				t.Errorf("loaded %+v, want addr %s, timeout %v, workers %d", *cfg, tc.addr, tc.timeout, tc.workers)
+			}
+		})
+	}
+}
+
+func TestVariableNamedByTag(t *testing.T) {
+	// The tag replaces the name derived from the prefix
+	t.Setenv("SECRET_PASSWORD", "s3cret")
+	t.Setenv("TEST_PASSWORD", "wrong")
+	cfg := defaults()
+	if _, err := Load(cfg, "test", "TEST_", nil); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Password != "s3cret" {
+		t.Errorf("password = %q", cfg.Password)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{name: "unknown key", args: []string{"-config", writeFile(t, "workrs: 2\n")}, want: "workrs"},
+		{name: "bad variable", env: map[string]string{"TEST_WORKERS": "many"}, want: "$TEST_WORKERS"},
+		{name: "bad flag", args: []string{"-timeout", "soon"}, want: "timeout"},
+		{name: "arguments", args: []string{"extra"}, want: "unexpected arguments"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			_, err := Load(defaults(), "test", "TEST_", tc.args)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Load = %v, want an error about %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadCommand(t *testing.T) {
+	cfg := defaults()
+	_, rest, err := LoadCommand(cfg, "test", "TEST_", "Usage: test <command>\n", []string{"-workers", "4", "grant", "John", "-x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Workers != 4 || strings.Join(rest, " ") != "grant John -x" {
+		t.Errorf("workers %d, arguments %q", cfg.Workers, rest)
+	}
+
+	if _, _, err := LoadCommand(defaults(), "test", "TEST_", "", []string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("-h = %v", err)
+	}
+}
+
+func TestPrintHidesSecrets(t *testing.T) {
+	cfg := defaults()
+	cfg.Password = "s3cret"
+	var out bytes.Buffer
+	if err := Print(&out, cfg); err != nil {
+		t.Fatal(err)
+	}
+	printed := out.String()
+	if strings.Contains(printed, "s3cret") || !strings.Contains(printed, "password: <redacted>") {
+		t.Errorf("printed secret:\n%s", printed)
+	}
+	// Unset secrets stay empty, so it shows they are unset
+	if !strings.Contains(printed, `unset: ""`) {
+		t.Errorf("unset secret shown as set:\n%s", printed)
+	}
+	if cfg.Password != "s3cret" {
+		t.Error("Print changed the settings")
+	}
+	if !strings.Contains(printed, "addr: default:1") {
+		t.Errorf("settings missing:\n%s", printed)
+	}
+}
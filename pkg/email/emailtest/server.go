@@ -0,0 +1,152 @@
+// Package emailtest provides an in-process SMTP server that records the
+// messages it receives, for exercising email delivery without a real relay.
+package emailtest
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Message is a message accepted by the server
+type Message struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Server is a minimal SMTP server listening on a loopback port
+type Server struct {
+	// Addr is the host:port the server listens on
+	Addr string
+
+	ln       net.Listener
+	mu       sync.Mutex
+	messages []Message
+	received chan Message
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a server on an ephemeral loopback port
+func NewServer() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		Addr:     ln.Addr().String(),
+		ln:       ln,
+		received: make(chan Message, 64),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Messages returns every message received so far
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+// Received delivers messages as they arrive; it is buffered and messages are
+// dropped from it, though not from Messages, when nobody is reading
+func (s *Server) Received() <-chan Message {
+	return s.received
+}
+
+// Close stops the server and waits for open sessions to end
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.session(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *Server) session(c *textproto.Conn) {
+	reply := func(code int, msg string) { c.PrintfLine("%d %s", code, msg) }
+
+	var msg Message
+	reply(220, "emailtest ready")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "HELO", "EHLO":
+			reply(250, "emailtest")
+		case "MAIL":
+			msg = Message{From: address(arg)}
+			reply(250, "OK")
+		case "RCPT":
+			msg.To = append(msg.To, address(arg))
+			reply(250, "OK")
+		case "DATA":
+			if len(msg.To) == 0 {
+				reply(503, "need RCPT")
+				continue
+			}
+			reply(354, "end data with <CR><LF>.<CR><LF>")
+			data, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = string(data)
+			s.store(msg)
+			msg = Message{}
+			reply(250, "OK")
+		case "RSET":
+			msg = Message{}
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, fmt.Sprintf("%s not implemented", verb))
+		}
+	}
+}
+
+func (s *Server) store(msg Message) {
+	s.mu.Lock()
+	s.messages = append(s.messages, msg)
+	s.mu.Unlock()
+
+	select {
+	case s.received <- msg:
+	default:
+	}
+}
+
+// address extracts the mailbox from "FROM:<a@b>" or "TO:<a@b>"
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
@@ -0,0 +1,98 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// queueSize bounds how many emails may wait for delivery
+const queueSize = 256
+
+// ErrQueueFull is reported when an email is dropped because too many are
+// already waiting for delivery
+var ErrQueueFull = errors.New("email: delivery queue full")
+
+// Notifier renders and sends notification emails in the background so that
+// slow mail relays never hold up bidding
+type Notifier struct {
+	from      string
+	renderer  *Renderer
+	transport Transport
+	timeout   time.Duration
+
+	queue   chan Message
+	mu      sync.Mutex
+	closed  bool
+	done    chan struct{}
+	onError func(Message, error)
+}
+
+// NewNotifier starts a notifier that sends from the given address
+func NewNotifier(from string, renderer *Renderer, transport Transport) *Notifier {
+	n := &Notifier{
+		from:      from,
+		renderer:  renderer,
+		transport: transport,
+		timeout:   30 * time.Second,
+		queue:     make(chan Message, queueSize),
+		done:      make(chan struct{}),
+		onError: func(msg Message, err error) {
+			slog.Error("Failed to email", "to", msg.To, "error", err)
+		},
+	}
+	go n.run()
+	return n
+}
+
+// Notify renders the template for kind and queues the email for delivery.
+// It returns false if the email could not be rendered or queued.
+func (n *Notifier) Notify(to, kind string, data interface{}) bool {
+	msg, err := n.renderer.Render(kind, data)
+	if err != nil {
+		n.onError(Message{To: []string{to}}, err)
+		return false
+	}
+	msg.From = n.from
+	msg.To = []string{to}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return false
+	}
+	select {
+	case n.queue <- msg:
+		return true
+	default:
+		n.onError(msg, ErrQueueFull)
+		return false
+	}
+}
+
+// Close stops accepting emails and waits for the queue to drain
+func (n *Notifier) Close() {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+
+	<-n.done
+}
+
+func (n *Notifier) run() {
+	defer close(n.done)
+
+	for msg := range n.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+		if err := n.transport.Send(ctx, msg); err != nil {
+			n.onError(msg, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,152 @@
+package email_test
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/930r91na/Subasta-grpc/pkg/email"
+	"github.com/930r91na/Subasta-grpc/pkg/email/emailtest"
+	"github.com/930r91na/Subasta-grpc/pkg/notify"
+)
+
+// bodies returns the plain text and HTML parts of a received message
+func bodies(t *testing.T, msg *mail.Message) (text, html string) {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type %q: want multipart/alternative", msg.Header.Get("Content-Type"))
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			return text, html
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch ct := part.Header.Get("Content-Type"); {
+		case strings.HasPrefix(ct, "text/plain"):
+			text = string(body)
+		case strings.HasPrefix(ct, "text/html"):
+			html = string(body)
+		}
+	}
+}
+
+func TestNotificationsDelivered(t *testing.T) {
+	srv, err := emailtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	renderer, err := email.NewRenderer(email.DefaultTemplates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notifier := email.NewNotifier("Auction House <auction@example.com>", renderer, &email.SMTPTransport{Addr: srv.Addr})
+	defer notifier.Close()
+
+	for _, tc := range []struct {
+		kind    notify.Kind
+		subject string
+		text    []string
+	}{
+		{notify.KindOutbid, "You have been outbid on Laptop", []string{"Hi Alice,", "Bob bid more on Laptop.", "The current price is $150.00."}},
+		{notify.KindWon, "You won Laptop", []string{"Congratulations Alice,", "Bob bid more on Laptop."}},
+		{notify.KindLost, "Auction ended: Laptop", []string{"Hi Alice,", "Bob bid more on Laptop."}},
+		{notify.KindItemSold, "Laptop has been sold", []string{"Hi Alice,", "Bob bid more on Laptop."}},
+		{notify.KindListingEnded, "Auction ended: Laptop", []string{"Hi Alice,", "Bob bid more on Laptop."}},
+		{notify.KindWatchedBid, "New bid on Laptop", []string{"Hi Alice,", "Bob bid more on Laptop."}},
+	} {
+		t.Run(string(tc.kind), func(t *testing.T) {
+			n := notify.Notification{
+				User:    "Alice",
+				Kind:    tc.kind,
+				Product: "Laptop",
+				Message: "Bob bid more on Laptop",
+				Amount:  150,
+			}
+			if !notifier.Notify("alice@example.com", string(tc.kind), n) {
+				t.Fatal("Notify failed")
+			}
+
+			var received emailtest.Message
+			select {
+			case received = <-srv.Received():
+			case <-time.After(5 * time.Second):
+				t.Fatal("no email received")
+			}
+			if received.From != "auction@example.com" {
+				t.Errorf("envelope sender = %q", received.From)
+			}
+			if len(received.To) != 1 || received.To[0] != "alice@example.com" {
+				t.Errorf("envelope recipients = %q", received.To)
+			}
+
+			msg, err := mail.ReadMessage(strings.NewReader(received.Data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if to := msg.Header.Get("To"); to != "alice@example.com" {
+				t.Errorf("To = %q", to)
+			}
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if subject != tc.subject {
+				t.Errorf("Subject = %q, want %q", subject, tc.subject)
+			}
+
+			text, html := bodies(t, msg)
+			for _, want := range tc.text {
+				if !strings.Contains(text, want) {
+					t.Errorf("text body %q lacks %q", text, want)
+				}
+			}
+			if !strings.Contains(html, "<p>Bob bid more on Laptop.</p>") {
+				t.Errorf("HTML body %q lacks the message", html)
+			}
+		})
+	}
+}
+
+func TestEveryKindHasTemplate(t *testing.T) {
+	renderer, err := email.NewRenderer(email.DefaultTemplates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kind := range []notify.Kind{
+		notify.KindOutbid, notify.KindWon, notify.KindLost,
+		notify.KindItemSold, notify.KindListingEnded, notify.KindWatchedBid,
+	} {
+		if _, err := renderer.Render(string(kind), notify.Notification{Kind: kind}); err != nil {
+			t.Errorf("%s: %v", kind, err)
+		}
+	}
+}
+
+func TestHTMLEscaped(t *testing.T) {
+	renderer, err := email.NewRenderer(email.DefaultTemplates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := renderer.Render(string(notify.KindWon), notify.Notification{User: "<b>Mallory</b>", Message: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(msg.HTML, "<b>") || !strings.Contains(msg.Text, "<b>Mallory</b>") {
+		t.Errorf("user name not escaped in HTML only: text %q, HTML %q", msg.Text, msg.HTML)
+	}
+}
@@ -0,0 +1,111 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// Template is the subject and bodies used for one kind of event
+type Template struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// DefaultTemplates cover every notification kind. Templates receive the
+// notification being delivered, with User, Product, Message and Amount fields.
+var DefaultTemplates = map[string]Template{
+	"outbid": {
+		Subject: `You have been outbid on {{.Product}}`,
+		Text:    "Hi {{.User}},\n\n{{.Message}}.\n\nThe current price is ${{printf \"%.2f\" .Amount}}. Place a higher bid to stay in the auction.\n",
+		HTML:    `<p>Hi {{.User}},</p><p>{{.Message}}.</p><p>The current price is <strong>${{printf "%.2f" .Amount}}</strong>. Place a higher bid to stay in the auction.</p>`,
+	},
+	"won": {
+		Subject: `You won {{.Product}}`,
+		Text:    "Congratulations {{.User}},\n\n{{.Message}}.\n",
+		HTML:    `<p>Congratulations {{.User}},</p><p>{{.Message}}.</p>`,
+	},
+	"lost": {
+		Subject: `Auction ended: {{.Product}}`,
+		Text:    "Hi {{.User}},\n\n{{.Message}}.\n",
+		HTML:    `<p>Hi {{.User}},</p><p>{{.Message}}.</p>`,
+	},
+	"item_sold": {
+		Subject: `{{.Product}} has been sold`,
+		Text:    "Hi {{.User}},\n\n{{.Message}}.\n",
+		HTML:    `<p>Hi {{.User}},</p><p>{{.Message}}.</p>`,
+	},
+	"listing_ended": {
+		Subject: `Auction ended: {{.Product}}`,
+		Text:    "Hi {{.User}},\n\n{{.Message}}.\n",
+		HTML:    `<p>Hi {{.User}},</p><p>{{.Message}}.</p>`,
+	},
+	"watched_bid": {
+		Subject: `New bid on {{.Product}}`,
+		Text:    "Hi {{.User}},\n\n{{.Message}}.\n",
+		HTML:    `<p>Hi {{.User}},</p><p>{{.Message}}.</p>`,
+	},
+}
+
+type compiled struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// Renderer turns event data into message subjects and bodies
+type Renderer struct {
+	templates map[string]compiled
+}
+
+// NewRenderer parses a set of templates keyed by event kind
+func NewRenderer(templates map[string]Template) (*Renderer, error) {
+	r := &Renderer{templates: make(map[string]compiled, len(templates))}
+	for kind, t := range templates {
+		var c compiled
+		var err error
+		if c.subject, err = texttemplate.New(kind + ".subject").Parse(t.Subject); err != nil {
+			return nil, err
+		}
+		if c.text, err = texttemplate.New(kind + ".txt").Parse(t.Text); err != nil {
+			return nil, err
+		}
+		if t.HTML != "" {
+			if c.html, err = htmltemplate.New(kind + ".html").Parse(t.HTML); err != nil {
+				return nil, err
+			}
+		}
+		r.templates[kind] = c
+	}
+	return r, nil
+}
+
+// Render fills in the templates for an event kind. The From and To fields
+// of the returned message are left empty.
+func (r *Renderer) Render(kind string, data interface{}) (Message, error) {
+	c, ok := r.templates[kind]
+	if !ok {
+		return Message{}, fmt.Errorf("email: no template for %q", kind)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := c.subject.Execute(&subject, data); err != nil {
+		return Message{}, err
+	}
+	if err := c.text.Execute(&text, data); err != nil {
+		return Message{}, err
+	}
+	if c.html != nil {
+		if err := c.html.Execute(&html, data); err != nil {
+			return Message{}, err
+		}
+	}
+
+	return Message{
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/mail"
+	"net/smtp"
+)
+
+// Transport delivers encoded messages
+type Transport interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPTransport delivers mail through an SMTP relay. STARTTLS is used when
+// the server offers it, and Auth is only attempted over TLS or to localhost,
+// as enforced by net/smtp.
+type SMTPTransport struct {
+	// Addr is the relay's host:port
+	Addr string
+	// Auth is optional, e.g. smtp.PlainAuth
+	Auth smtp.Auth
+	// TLSConfig is used for STARTTLS; defaults to verifying the relay's host
+	TLSConfig *tls.Config
+}
+
+// Send delivers a message, honouring the context's deadline for the whole
+// SMTP conversation
+func (t *SMTPTransport) Send(ctx context.Context, msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	host, _, err := net.SplitHostPort(t.Addr)
+	if err != nil {
+		return err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", t.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		cfg := t.TLSConfig
+		if cfg == nil {
+			cfg = &tls.Config{ServerName: host}
+		}
+		if err := c.StartTLS(cfg); err != nil {
+			return err
+		}
+	}
+	if t.Auth != nil {
+		if err := c.Auth(t.Auth); err != nil {
+			return err
+		}
+	}
+
+	from, _ := mail.ParseAddress(msg.From)
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		addr, _ := mail.ParseAddress(to)
+		if err := c.Rcpt(addr.Address); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
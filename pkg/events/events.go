@@ -0,0 +1,247 @@
+// Package events records every change to the auction's state as a typed
+// event. The state itself is never stored; it is rebuilt by folding the
+// events in order, which lets any past state be reconstructed and lets read
+// models be built as projections of the same events.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Type names a kind of event
+type Type string
+
+// Event types
+const (
+	TypeUserRegistered   Type = "user.registered"
+	TypeProfileUpdated   Type = "user.profile_updated"
+	TypeUserSuspended    Type = "user.suspended"
+	TypeProductListed    Type = "product.listed"
+	TypeProductFrozen    Type = "product.frozen"
+	TypeProductRemoved   Type = "product.removed"
+	TypeBidPlaced        Type = "bid.placed"
+	TypeBidVoided        Type = "bid.voided"
+	TypeAuctionClosed    Type = "auction.closed"
+	TypeWatchAdded       Type = "watch.added"
+	TypeWatchRemoved     Type = "watch.removed"
+	TypeRoleGranted      Type = "role.granted"
+	TypeRoleRevoked      Type = "role.revoked"
+	TypeNotificationRead Type = "notification.read"
+)
+
+// Payload is the data of one kind of event
+type Payload interface {
+	Type() Type
+}
+
+// UserRegistered records a new user
+type UserRegistered struct {
+	Name string `json:"name"`
+}
+
+// ProfileUpdated records a user's new email settings
+type ProfileUpdated struct {
+	Name         string   `json:"name"`
+	Email        string   `json:"email"`
+	EmailEnabled bool     `json:"email_enabled"`
+	EmailEvents  []string `json:"email_events,omitempty"`
+}
+
+// UserSuspended records a moderator suspending or reinstating a user.
+// Actor names the moderator here and in the other moderation events.
+type UserSuspended struct {
+	Name      string `json:"name"`
+	Suspended bool   `json:"suspended"`
+	Reason    string `json:"reason,omitempty"`
+	Actor     string `json:"actor,omitempty"`
+}
+
+// ProductListed records a product put up for auction
+type ProductListed struct {
+	Seller       string  `json:"seller"`
+	Product      string  `json:"product"`
+	InitialPrice float32 `json:"initial_price"`
+}
+
+// ProductFrozen records a moderator freezing or unfreezing an auction
+type ProductFrozen struct {
+	Product string `json:"product"`
+	Frozen  bool   `json:"frozen"`
+	Reason  string `json:"reason,omitempty"`
+	Actor   string `json:"actor,omitempty"`
+}
+
+// ProductRemoved records a moderator deleting a listing
+type ProductRemoved struct {
+	Product string `json:"product"`
+	Reason  string `json:"reason,omitempty"`
+	Actor   string `json:"actor,omitempty"`
+}
+
+// BidPlaced records an accepted bid, which is now the highest
+type BidPlaced struct {
+	Buyer   string  `json:"buyer"`
+	Product string  `json:"product"`
+	Amount  float32 `json:"amount"`
+}
+
+// BidVoided records a moderator removing a buyer's bid
+type BidVoided struct {
+	Buyer   string `json:"buyer"`
+	Product string `json:"product"`
+	Reason  string `json:"reason,omitempty"`
+	Actor   string `json:"actor,omitempty"`
+}
+
+// AuctionClosed records the end of an auction. Commission is the house's
+// cut in cents, fixed when the auction closed. Actor is the moderator who
+// forced it closed, if one did.
+type AuctionClosed struct {
+	Product    string  `json:"product"`
+	Seller     string  `json:"seller"`
+	Winner     string  `json:"winner,omitempty"`
+	Price      float32 `json:"price"`
+	Commission int64   `json:"commission,omitempty"`
+	Actor      string  `json:"actor,omitempty"`
+	Reason     string  `json:"reason,omitempty"`
+}
+
+// WatchAdded records a user starting to watch a product
+type WatchAdded struct {
+	User    string `json:"user"`
+	Product string `json:"product"`
+}
+
+// WatchRemoved records a user no longer watching a product
+type WatchRemoved struct {
+	User    string `json:"user"`
+	Product string `json:"product"`
+}
+
+// RoleGranted records an admin giving a user or staff member a role
+type RoleGranted struct {
+	User   string `json:"user"`
+	Role   string `json:"role"`
+	Reason string `json:"reason,omitempty"`
+	Actor  string `json:"actor,omitempty"`
+}
+
+// RoleRevoked records an admin taking a role away
+type RoleRevoked struct {
+	User   string `json:"user"`
+	Role   string `json:"role"`
+	Reason string `json:"reason,omitempty"`
+	Actor  string `json:"actor,omitempty"`
+}
+
+// NotificationRead records a user reading one of their notifications, or
+// all of them if ID is zero
+type NotificationRead struct {
+	User string `json:"user"`
+	ID   int64  `json:"id,omitempty"`
+}
+
+func (*UserRegistered) Type() Type   { return TypeUserRegistered }
+func (*ProfileUpdated) Type() Type   { return TypeProfileUpdated }
+func (*UserSuspended) Type() Type    { return TypeUserSuspended }
+func (*ProductListed) Type() Type    { return TypeProductListed }
+func (*ProductFrozen) Type() Type    { return TypeProductFrozen }
+func (*ProductRemoved) Type() Type   { return TypeProductRemoved }
+func (*BidPlaced) Type() Type        { return TypeBidPlaced }
+func (*BidVoided) Type() Type        { return TypeBidVoided }
+func (*AuctionClosed) Type() Type    { return TypeAuctionClosed }
+func (*WatchAdded) Type() Type       { return TypeWatchAdded }
+func (*WatchRemoved) Type() Type     { return TypeWatchRemoved }
+func (*RoleGranted) Type() Type      { return TypeRoleGranted }
+func (*RoleRevoked) Type() Type      { return TypeRoleRevoked }
+func (*NotificationRead) Type() Type { return TypeNotificationRead }
+
+// payloads creates an empty payload for each event type when decoding
+var payloads = map[Type]func() Payload{
+	TypeUserRegistered:   func() Payload { return &UserRegistered{} },
+	TypeProfileUpdated:   func() Payload { return &ProfileUpdated{} },
+	TypeUserSuspended:    func() Payload { return &UserSuspended{} },
+	TypeProductListed:    func() Payload { return &ProductListed{} },
+	TypeProductFrozen:    func() Payload { return &ProductFrozen{} },
+	TypeProductRemoved:   func() Payload { return &ProductRemoved{} },
+	TypeBidPlaced:        func() Payload { return &BidPlaced{} },
+	TypeBidVoided:        func() Payload { return &BidVoided{} },
+	TypeAuctionClosed:    func() Payload { return &AuctionClosed{} },
+	TypeWatchAdded:       func() Payload { return &WatchAdded{} },
+	TypeWatchRemoved:     func() Payload { return &WatchRemoved{} },
+	TypeRoleGranted:      func() Payload { return &RoleGranted{} },
+	TypeRoleRevoked:      func() Payload { return &RoleRevoked{} },
+	TypeNotificationRead: func() Payload { return &NotificationRead{} },
+}
+
+// Event is one recorded state change
+type Event struct {
+	Seq  uint64
+	Time time.Time
+	Data Payload
+}
+
+// Type returns the type of the event's payload
+func (e Event) Type() Type {
+	return e.Data.Type()
+}
+
+// Product returns the product an event is about, or "" for user events
+func (e Event) Product() string {
+	switch d := e.Data.(type) {
+	case *ProductListed:
+		return d.Product
+	case *ProductFrozen:
+		return d.Product
+	case *ProductRemoved:
+		return d.Product
+	case *BidPlaced:
+		return d.Product
+	case *BidVoided:
+		return d.Product
+	case *AuctionClosed:
+		return d.Product
+	case *WatchAdded:
+		return d.Product
+	case *WatchRemoved:
+		return d.Product
+	}
+	return ""
+}
+
+// record is the stored form of an event
+type record struct {
+	Seq  uint64          `json:"seq"`
+	Time time.Time       `json:"time"`
+	Type Type            `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// MarshalJSON encodes the event with its type next to its payload
+func (e Event) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(record{Seq: e.Seq, Time: e.Time, Type: e.Type(), Data: data})
+}
+
+// UnmarshalJSON decodes an event written by MarshalJSON
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil {
+		return err
+	}
+	newPayload, ok := payloads[r.Type]
+	if !ok {
+		return fmt.Errorf("events: unknown event type %q", r.Type)
+	}
+	data := newPayload()
+	if err := json.Unmarshal(r.Data, data); err != nil {
+		return fmt.Errorf("events: %s: %w", r.Type, err)
+	}
+	*e = Event{Seq: r.Seq, Time: r.Time, Data: data}
+	return nil
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"errors"
+	"sync"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrFeedGap is returned when subscribing after changes the feed no longer
+// keeps
+var ErrFeedGap = errors.New("events: changes no longer kept")
+
+// Change is an event about a product, with the product as it is after it
+type Change struct {
+	Event Event
+	// Info is an immutable copy of the product, or nil once it was removed
+	Info *pb.ProductInfo
+}
+
+// Feed is a projection streaming the changes to products to subscribers as
+// they are recorded. It keeps the latest changes, so a subscriber that lost
+// its connection can pick up where it left off. It is safe for concurrent
+// use.
+type Feed struct {
+	mu   sync.Mutex
+	live map[string]*Product
+	// recent are the latest changes, oldest first; kept is every change
+	// after sequence number since
+	recent []Change
+	keep   int
+	since  uint64
+	subs   map[*Subscription]struct{}
+}
+
+// Subscription receives a feed's changes on C. C is closed when the
+// subscription is cancelled, or when the subscriber falls so far behind
+// that its buffer fills up; it can then subscribe again after the last
+// change it received.
+type Subscription struct {
+	C    <-chan Change
+	c    chan Change
+	feed *Feed
+	// Dropped is set once C was closed because the subscriber fell behind
+	Dropped bool
+}
+
+// NewFeed creates a feed keeping the latest keep changes; feed it with
+// Log.Project
+func NewFeed(keep int) *Feed {
+	return &Feed{live: make(map[string]*Product), keep: keep, subs: make(map[*Subscription]struct{})}
+}
+
+// Apply records the change an event made to a product and passes it on
+func (f *Feed) Apply(e Event) {
+	name := e.Product()
+	if name == "" {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var info *pb.ProductInfo
+	switch d := e.Data.(type) {
+	case *ProductListed:
+		p := NewProduct(d)
+		f.live[name] = p
+		info = proto.Clone(p.Info).(*pb.ProductInfo)
+
+	case *ProductRemoved:
+		delete(f.live, name)
+
+	default:
+		p, ok := f.live[name]
+		if !ok {
+			return
+		}
+		p.Apply(e)
+		info = proto.Clone(p.Info).(*pb.ProductInfo)
+	}
+
+	c := Change{Event: e, Info: info}
+	f.recent = append(f.recent, c)
+	if len(f.recent) > f.keep {
+		f.since = f.recent[0].Event.Seq
+		f.recent = f.recent[1:]
+	}
+
+	for sub := range f.subs {
+		select {
+		case sub.c <- c:
+		default:
+			sub.Dropped = true
+			f.drop(sub)
+		}
+	}
+}
+
+// Subscribe returns the changes recorded after sequence number after,
+// followed by every change recorded from now on, buffering up to buffer of
+// them. after 0 starts with the changes from now on. It returns ErrFeedGap
+// if the changes since after are no longer kept.
+func (f *Feed) Subscribe(after uint64, buffer int) ([]Change, *Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var backlog []Change
+	if after > 0 {
+		if after < f.since {
+			return nil, nil, ErrFeedGap
+		}
+		for _, c := range f.recent {
+			if c.Event.Seq > after {
+				backlog = append(backlog, c)
+			}
+		}
+	}
+
+	c := make(chan Change, buffer)
+	sub := &Subscription{C: c, c: c, feed: f}
+	f.subs[sub] = struct{}{}
+	return backlog, sub, nil
+}
+
+// Cancel ends the subscription
+func (s *Subscription) Cancel() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.drop(s)
+}
+
+// drop ends a subscription; callers hold f.mu
+func (f *Feed) drop(sub *Subscription) {
+	if _, ok := f.subs[sub]; ok {
+		delete(f.subs, sub)
+		close(sub.c)
+	}
+}
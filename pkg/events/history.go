@@ -0,0 +1,102 @@
+package events
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+	"google.golang.org/protobuf/proto"
+)
+
+// History is a projection keeping every version of every product, so the
+// catalog can be read as it was at any moment without replaying the log.
+// It is safe for concurrent use.
+type History struct {
+	mu sync.RWMutex
+	// live folds the events into the current state of each product
+	live      map[string]*Product
+	timelines map[string][]version
+}
+
+// version is the state of a product from a moment until its next event
+type version struct {
+	time time.Time
+	// info is an immutable copy, or nil once the product was removed
+	info *pb.ProductInfo
+}
+
+// NewHistory creates an empty history; feed it with Log.Project
+func NewHistory() *History {
+	return &History{
+		live:      make(map[string]*Product),
+		timelines: make(map[string][]version),
+	}
+}
+
+// Apply records the state of the product an event changed
+func (h *History) Apply(e Event) {
+	name := e.Product()
+	if name == "" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var info *pb.ProductInfo
+	switch d := e.Data.(type) {
+	case *ProductListed:
+		p := NewProduct(d)
+		h.live[name] = p
+		info = proto.Clone(p.Info).(*pb.ProductInfo)
+
+	case *ProductRemoved:
+		delete(h.live, name)
+
+	default:
+		p, ok := h.live[name]
+		if !ok {
+			return
+		}
+		p.Apply(e)
+		info = proto.Clone(p.Info).(*pb.ProductInfo)
+	}
+	h.timelines[name] = append(h.timelines[name], version{time: e.Time, info: info})
+}
+
+// Product returns a product as it was at t, or false if it was not listed
+// then. The result must not be modified.
+func (h *History) Product(name string, t time.Time) (*pb.ProductInfo, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	info := at(h.timelines[name], t)
+	return info, info != nil
+}
+
+// Catalog returns every product listed at t, sorted by name. The results
+// must not be modified.
+func (h *History) Catalog(t time.Time) []*pb.ProductInfo {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	products := make([]*pb.ProductInfo, 0, len(h.timelines))
+	for _, timeline := range h.timelines {
+		if info := at(timeline, t); info != nil {
+			products = append(products, info)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Product < products[j].Product })
+	return products
+}
+
+// at finds the version of a timeline in effect at t. Event times never go
+// backwards, so timelines are sorted by time.
+func at(timeline []version, t time.Time) *pb.ProductInfo {
+	i := sort.Search(len(timeline), func(i int) bool { return timeline[i].time.After(t) })
+	if i == 0 {
+		return nil
+	}
+	return timeline[i-1].info
+}
@@ -0,0 +1,78 @@
+package events
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	h := NewHistory()
+	for i, data := range []Payload{
+		&ProductListed{Seller: "seller", Product: "lamp", InitialPrice: 10},
+		&BidPlaced{Buyer: "Alice", Product: "lamp", Amount: 12},
+		&ProductListed{Seller: "seller", Product: "vase", InitialPrice: 5},
+		&BidPlaced{Buyer: "Bob", Product: "lamp", Amount: 15},
+		&BidVoided{Buyer: "Bob", Product: "lamp"},
+		&ProductRemoved{Product: "lamp"},
+		&ProductListed{Seller: "other", Product: "lamp", InitialPrice: 50},
+	} {
+		// Event i+1 happens i+1 seconds in
+		h.Apply(Event{Seq: uint64(i + 1), Time: at(i + 1), Data: data})
+	}
+
+	tests := []struct {
+		name    string
+		t       time.Time
+		found   bool
+		price   float32
+		bidder  string
+		version uint64
+	}{
+		{"before listing", at(0), false, 0, "", 0},
+		{"listed", at(1), true, 10, "", 1},
+		{"between events", at(2).Add(time.Second / 2), true, 12, "Alice", 2},
+		{"outbid", at(4), true, 15, "Bob", 3},
+		{"bid voided", at(5), true, 12, "Alice", 4},
+		{"removed", at(6), false, 0, "", 0},
+		{"listed again", at(7), true, 50, "", 1},
+		{"now", at(100), true, 50, "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, found := h.Product("lamp", tt.t)
+			if found != tt.found {
+				t.Fatalf("Product(lamp) found = %v, want %v", found, tt.found)
+			}
+			if !found {
+				return
+			}
+			if info.CurrentPrice != tt.price || info.HighestBidder != tt.bidder || info.Version != tt.version {
+				t.Errorf("Product(lamp) = %.2f by %q at version %d, want %.2f by %q at version %d",
+					info.CurrentPrice, info.HighestBidder, info.Version, tt.price, tt.bidder, tt.version)
+			}
+		})
+	}
+
+	for _, c := range []struct {
+		t    time.Time
+		want []string
+	}{
+		{at(0), nil},
+		{at(2), []string{"lamp"}},
+		{at(3), []string{"lamp", "vase"}},
+		{at(6), []string{"vase"}},
+		{at(7), []string{"lamp", "vase"}},
+	} {
+		var got []string
+		for _, info := range h.Catalog(c.t) {
+			got = append(got, info.Product)
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("Catalog(%v) = %v, want %v", c.t.Sub(start), got, c.want)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when appending to a closed log
+var ErrClosed = errors.New("events: log closed")
+
+// Projection builds a read model from events
+type Projection interface {
+	Apply(e Event)
+}
+
+// Replicator commits events to the other nodes of a cluster
+type Replicator interface {
+	// Propose starts committing e after every event proposed before it,
+	// or fails if this node may not append events. Once committed, e is
+	// kept with Receive; wait returns when it was, or why it was not.
+	Propose(e Event) (wait func() error, err error)
+}
+
+// ProjectionFunc adapts a function to a Projection
+type ProjectionFunc func(e Event)
+
+// Apply calls f(e)
+func (f ProjectionFunc) Apply(e Event) { f(e) }
+
+// Log is an append-only sequence of events, safe for concurrent use. When
+// opened with a path, events are written to that file as JSON lines.
+type Log struct {
+	mu          sync.Mutex
+	file        *os.File
+	events      []Event
+	projections []Projection
+	replicator  Replicator
+	closed      bool
+	now         func() time.Time
+
+	// propose serializes proposals to the replicator, which commits them
+	// in order, and is taken before mu. Replicated events are kept with
+	// Receive, which only needs mu, so committing never waits for the
+	// next proposal. proposed is the latest event proposed while any is
+	// still awaiting commit.
+	propose  sync.Mutex
+	proposed Event
+	inflight int
+
+	// projecting hands kept events to the projections after mu is
+	// released, one at a time and in order: an event waits its turn until
+	// every earlier one was projected. projected is the latest that was.
+	projecting sync.Mutex
+	turn       sync.Cond
+	projected  uint64
+}
+
+// Open opens the event log at path, loading the events already recorded
+// there. An empty path keeps the log in memory only. A final line cut short
+// by a crash is discarded.
+func Open(path string) (*Log, error) {
+	l := &Log{now: time.Now}
+	l.turn.L = &l.projecting
+	if path == "" {
+		return l, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	good, err := l.load(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("events: %s: %w", path, err)
+	}
+	if err := f.Truncate(good); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(good, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	l.file = f
+	l.projected = l.lastSeq()
+	return l, nil
+}
+
+// ReadFile returns the events recorded in the log file at path. The file
+// is only read, so the log of a running server can be inspected.
+func ReadFile(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var l Log
+	if _, err := l.load(f); err != nil {
+		return nil, fmt.Errorf("events: %s: %w", path, err)
+	}
+	return l.events, nil
+}
+
+// load reads the events in r and returns the length of the complete lines
+func (l *Log) load(r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+	var good int64
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Anything after the last newline is an unfinished write
+			return good, nil
+		}
+		if err != nil {
+			return good, err
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var e Event
+			if err := json.Unmarshal(line, &e); err != nil {
+				return good, fmt.Errorf("event after seq %d: %w", l.lastSeq(), err)
+			}
+			l.events = append(l.events, e)
+		}
+		good += int64(len(line))
+	}
+}
+
+// lastSeq returns the sequence number of the latest event; callers hold l.mu
+func (l *Log) lastSeq() uint64 {
+	if len(l.events) == 0 {
+		return 0
+	}
+	return l.events[len(l.events)-1].Seq
+}
+
+// next numbers and timestamps the event following every event kept or
+// proposed; callers hold l.mu, and l.propose when replicating
+func (l *Log) next(data Payload) Event {
+	var last Event
+	if n := len(l.events); n > 0 {
+		last = l.events[n-1]
+	}
+	if l.inflight > 0 && l.proposed.Seq > last.Seq {
+		last = l.proposed
+	}
+	e := Event{Seq: last.Seq + 1, Time: l.now(), Data: data}
+	if e.Time.Before(last.Time) {
+		e.Time = last.Time
+	}
+	return e
+}
+
+// Append records a change, numbering and timestamping it, and hands it to
+// every projection before returning. The event is only kept if it could be
+// replicated and written. Event times never go backwards, even if the
+// clock does.
+func (l *Log) Append(data Payload) (Event, error) {
+	l.mu.Lock()
+	r := l.replicator
+	if r != nil {
+		l.mu.Unlock()
+		return l.replicate(r, data)
+	}
+	if l.closed {
+		l.mu.Unlock()
+		return Event{}, ErrClosed
+	}
+	e := l.next(data)
+	err := l.keep(e)
+	l.mu.Unlock()
+	if err != nil {
+		return Event{}, err
+	}
+	l.project(e)
+	return e, nil
+}
+
+// replicate proposes an event to r and waits until r has kept it. Events
+// appended at the same time are committed together rather than one after
+// the other.
+func (l *Log) replicate(r Replicator, data Payload) (Event, error) {
+	l.propose.Lock()
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		l.propose.Unlock()
+		return Event{}, ErrClosed
+	}
+	e := l.next(data)
+	l.mu.Unlock()
+
+	wait, err := r.Propose(e)
+	if err != nil {
+		l.propose.Unlock()
+		return Event{}, err
+	}
+	l.proposed = e
+	l.inflight++
+	l.propose.Unlock()
+
+	err = wait()
+
+	// Once nothing awaits commit, the next event follows the last one
+	// kept, whatever became of those that failed
+	l.propose.Lock()
+	l.inflight--
+	l.propose.Unlock()
+	if err != nil {
+		return Event{}, err
+	}
+	return e, nil
+}
+
+// ReplicateTo makes Append commit every event through r, which keeps it
+// with Receive. Proposals are made one at a time, so r must not append to
+// the log before returning from Propose.
+func (l *Log) ReplicateTo(r Replicator) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.replicator = r
+}
+
+// Receive records an event appended on another node, keeping its number
+// and time, and hands it to every projection. It reports false for an event
+// that was already recorded.
+func (l *Log) Receive(e Event) (bool, error) {
+	if kept, err := l.receive(e); !kept || err != nil {
+		return kept, err
+	}
+	l.project(e)
+	return true, nil
+}
+
+// receive keeps an event from another node under l.mu
+func (l *Log) receive(e Event) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return false, ErrClosed
+	}
+	last := l.lastSeq()
+	if e.Seq <= last {
+		return false, nil
+	}
+	if e.Seq != last+1 {
+		return false, fmt.Errorf("events: received event %d after %d", e.Seq, last)
+	}
+	if err := l.keep(e); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// keep writes an event and adds it to the log; callers hold l.mu
+func (l *Log) keep(e Event) error {
+	if l.file != nil {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := l.file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+
+	l.events = append(l.events, e)
+	return nil
+}
+
+// project hands a kept event to every projection once every earlier event
+// was. Only the projections wait for each other; appending goes on.
+func (l *Log) project(e Event) {
+	l.projecting.Lock()
+	defer l.projecting.Unlock()
+
+	for l.projected < e.Seq-1 {
+		l.turn.Wait()
+	}
+	for _, p := range l.projections {
+		p.Apply(e)
+	}
+	l.projected = e.Seq
+	l.turn.Broadcast()
+}
+
+// Events returns the recorded events in order
+func (l *Log) Events() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]Event(nil), l.events...)
+}
+
+// Replay folds every recorded event into p, in order
+func (l *Log) Replay(p Projection) {
+	for _, e := range l.Events() {
+		p.Apply(e)
+	}
+}
+
+// Project replays the recorded events into p and then keeps it up to date
+// with every event appended later. p is called with no lock on the log
+// held, but never for two events at once and always in order, and it holds
+// up every other projection meanwhile, so it should be quick. It must not
+// append events itself.
+func (l *Log) Project(p Projection) {
+	l.projecting.Lock()
+	defer l.projecting.Unlock()
+
+	// Events kept but not yet projected reach p in their turn
+	for _, e := range l.Events() {
+		if e.Seq > l.projected {
+			break
+		}
+		p.Apply(e)
+	}
+	l.projections = append(l.projections, p)
+}
+
+// Close closes the log file
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed || l.file == nil {
+		l.closed = true
+		return nil
+	}
+	l.closed = true
+	return l.file.Close()
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestProjectedInOrder checks that events appended at the same time reach
+// projections one at a time and in order, each before its Append returns,
+// and that a reopened log projects what was written
+func TestProjectedInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		seqs   []uint64
+		inside bool
+	)
+	l.Project(ProjectionFunc(func(e Event) {
+		if inside {
+			t.Error("projected two events at once")
+		}
+		inside = true
+		seqs = append(seqs, e.Seq)
+		inside = false
+	}))
+
+	const n = 200
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e, err := l.Append(&UserRegistered{Name: fmt.Sprint("user", i)})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			l.projecting.Lock()
+			defer l.projecting.Unlock()
+			if l.projected < e.Seq {
+				t.Errorf("Append(%d) returned before it was projected", e.Seq)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seqs) != n {
+		t.Fatalf("projected %d events, want %d", len(seqs), n)
+	}
+	for i, seq := range seqs {
+		if seq != uint64(i+1) {
+			t.Fatalf("event %d projected as %d: %v", i+1, seq, seqs)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	count := 0
+	reopened.Project(ProjectionFunc(func(Event) { count++ }))
+	if _, err := reopened.Append(&UserRegistered{Name: "late"}); err != nil {
+		t.Fatal(err)
+	}
+	if count != n+1 {
+		t.Errorf("reopened log projected %d events, want %d", count, n+1)
+	}
+}
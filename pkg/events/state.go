@@ -0,0 +1,128 @@
+package events
+
+import (
+	pb "github.com/930r91na/Subasta-grpc/pkg/auction"
+)
+
+// Product is the state of one listing folded from its events
+type Product struct {
+	Info     *pb.ProductInfo
+	Bids     map[string]*pb.BidInfo // by buyer
+	Watchers map[string]bool
+}
+
+// NewProduct starts the state of a newly listed product
+func NewProduct(d *ProductListed) *Product {
+	return &Product{
+		Info: &pb.ProductInfo{
+			Seller:       d.Seller,
+			Product:      d.Product,
+			InitialPrice: d.InitialPrice,
+			CurrentPrice: d.InitialPrice,
+			Version:      1,
+		},
+		Bids:     make(map[string]*pb.BidInfo),
+		Watchers: make(map[string]bool),
+	}
+}
+
+// Apply folds one of the product's events into its state. The version
+// changes with the price, highest bidder or state of the auction, but not
+// with its watchers.
+func (p *Product) Apply(e Event) {
+	info := p.Info
+
+	switch d := e.Data.(type) {
+	case *BidPlaced:
+		info.CurrentPrice = d.Amount
+		info.HighestBidder = d.Buyer
+		info.Version++
+		p.Bids[d.Buyer] = &pb.BidInfo{Buyer: d.Buyer, Product: d.Product, Amount: d.Amount}
+
+	case *BidVoided:
+		delete(p.Bids, d.Buyer)
+		info.CurrentPrice = info.InitialPrice
+		info.HighestBidder = ""
+		for _, bid := range p.Bids {
+			if bid.Amount > info.CurrentPrice {
+				info.CurrentPrice = bid.Amount
+				info.HighestBidder = bid.Buyer
+			}
+		}
+		info.Version++
+
+	case *ProductFrozen:
+		info.Frozen = d.Frozen
+		info.Version++
+
+	case *AuctionClosed:
+		info.Closed = true
+		info.Frozen = false
+		info.Version++
+
+	case *WatchAdded:
+		p.Watchers[d.User] = true
+		info.Watchers = int32(len(p.Watchers))
+
+	case *WatchRemoved:
+		delete(p.Watchers, d.User)
+		info.Watchers = int32(len(p.Watchers))
+	}
+}
+
+// ApplyUser folds a user event into the users it changes
+func ApplyUser(users map[string]*pb.User, e Event) {
+	switch d := e.Data.(type) {
+	case *UserRegistered:
+		users[d.Name] = &pb.User{Name: d.Name}
+
+	case *ProfileUpdated:
+		if u, ok := users[d.Name]; ok {
+			u.Email = d.Email
+			u.EmailEnabled = d.EmailEnabled
+			u.EmailEvents = append([]string(nil), d.EmailEvents...)
+		}
+
+	case *UserSuspended:
+		if u, ok := users[d.Name]; ok {
+			u.Suspended = d.Suspended
+		}
+	}
+}
+
+// State is the whole auction folded from its events
+type State struct {
+	// Seq is the sequence number of the last event applied
+	Seq      uint64
+	Users    map[string]*pb.User
+	Products map[string]*Product
+}
+
+// NewState creates an empty state
+func NewState() *State {
+	return &State{
+		Users:    make(map[string]*pb.User),
+		Products: make(map[string]*Product),
+	}
+}
+
+// Apply folds an event into the state
+func (st *State) Apply(e Event) {
+	st.Seq = e.Seq
+
+	switch d := e.Data.(type) {
+	case *UserRegistered, *ProfileUpdated, *UserSuspended:
+		ApplyUser(st.Users, e)
+
+	case *ProductListed:
+		st.Products[d.Product] = NewProduct(d)
+
+	case *ProductRemoved:
+		delete(st.Products, d.Product)
+
+	default:
+		if p, ok := st.Products[e.Product()]; ok {
+			p.Apply(e)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Package idempotency remembers the results of requests by key, so that a
+// retried request gets the original result instead of running again.
+package idempotency
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrKeyReused is returned when a key comes back with a different request
+var ErrKeyReused = errors.New("idempotency key reused for a different request")
+
+// entry is the outcome of one keyed request. done is closed once the first
+// request with the key has finished.
+type entry struct {
+	key         string
+	fingerprint []byte
+	done        chan struct{}
+	result      interface{}
+	expires     time.Time
+}
+
+// Store remembers request results for a limited time, in memory
+type Store struct {
+	ttl time.Duration
+	max int
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	// results are the entries holding a result, in the order they expire
+	results []*entry
+}
+
+// NewStore creates a store keeping results for ttl, and at most max of
+// them: past that, the oldest are forgotten early
+func NewStore(ttl time.Duration, max int) *Store {
+	return &Store{
+		ttl:     ttl,
+		max:     max,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Do runs fn once per key and returns its result. Later calls with the same
+// key and fingerprint within the TTL get that result back, with replayed
+// set; if the first call is still running they wait for it. A fingerprint
+// that differs from the first call's yields ErrKeyReused.
+//
+// Failed calls, and calls that panic, are not remembered, so they can be
+// retried with the same key.
+func (s *Store) Do(key string, fingerprint []byte, fn func() (interface{}, error)) (result interface{}, replayed bool, err error) {
+	s.mu.Lock()
+	now := time.Now()
+	s.sweep(now)
+
+	if e, ok := s.entries[key]; ok {
+		s.mu.Unlock()
+		if !bytes.Equal(e.fingerprint, fingerprint) {
+			return nil, false, ErrKeyReused
+		}
+		<-e.done
+		if e.result == nil {
+			// The first call failed and was forgotten; run this one instead
+			return s.Do(key, fingerprint, fn)
+		}
+		return e.result, true, nil
+	}
+
+	e := &entry{key: key, fingerprint: fingerprint, done: make(chan struct{})}
+	s.entries[key] = e
+	s.mu.Unlock()
+
+	// Deferred, so callers waiting for this one are released even if fn
+	// panics
+	defer func() {
+		s.mu.Lock()
+		if err != nil || result == nil {
+			delete(s.entries, key)
+		} else {
+			e.result = result
+			e.expires = time.Now().Add(s.ttl)
+			s.results = append(s.results, e)
+		}
+		s.mu.Unlock()
+		close(e.done)
+	}()
+
+	result, err = fn()
+	return result, false, err
+}
+
+// sweep drops expired results, and the oldest beyond the store's capacity;
+// callers hold s.mu
+func (s *Store) sweep(now time.Time) {
+	for len(s.results) > 0 {
+		e := s.results[0]
+		if now.Before(e.expires) && len(s.entries) < s.max {
+			return
+		}
+		s.results[0] = nil
+		s.results = s.results[1:]
+		if s.entries[e.key] == e {
+			delete(s.entries, e.key)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package idempotency
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	fail := errors.New("failed")
+	for _, tc := range []struct {
+		name string
+		// first and second are the fingerprints and results of two calls
+		// with the same key, the first finishing before the second starts
+		first, second       string
+		firstErr, secondErr error
+		wantRuns            int
+		wantResult          interface{}
+		wantReplayed        bool
+		wantErr             error
+	}{
+		{name: "replay", first: "a", second: "a", wantRuns: 1, wantResult: 1, wantReplayed: true},
+		{name: "key reused", first: "a", second: "b", wantRuns: 1, wantErr: ErrKeyReused},
+		{name: "failed call forgotten", first: "a", second: "a", firstErr: fail, wantRuns: 2, wantResult: 2},
+		{name: "retry fails again", first: "a", second: "a", firstErr: fail, secondErr: fail, wantRuns: 2, wantErr: fail},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewStore(time.Hour, 10)
+			runs := 0
+			call := func(err error) func() (interface{}, error) {
+				return func() (interface{}, error) {
+					runs++
+					if err != nil {
+						return nil, err
+					}
+					return runs, nil
+				}
+			}
+			if _, _, err := s.Do("key", []byte(tc.first), call(tc.firstErr)); err != tc.firstErr {
+				t.Fatalf("first call: %v", err)
+			}
+			result, replayed, err := s.Do("key", []byte(tc.second), call(tc.secondErr))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("err = %v, want %v", err, tc.wantErr)
+			}
+			if err == nil && (result != tc.wantResult || replayed != tc.wantReplayed) {
+				t.Errorf("got %v, replayed %v; want %v, replayed %v", result, replayed, tc.wantResult, tc.wantReplayed)
+			}
+			if runs != tc.wantRuns {
+				t.Errorf("ran %d times, want %d", runs, tc.wantRuns)
+			}
+		})
+	}
+}
+
+// TestDoWaits checks that a duplicate arriving while the first call runs
+// waits for its result, and that a panicking call releases it
+func TestDoWaits(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		panics       bool
+		wantReplayed bool
+	}{
+		{name: "result", wantReplayed: true},
+		{name: "panic", panics: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewStore(time.Hour, 10)
+			started, release := make(chan struct{}), make(chan struct{})
+			var runs atomic.Int32
+			go func() {
+				defer func() { recover() }()
+				s.Do("key", nil, func() (interface{}, error) {
+					runs.Add(1)
+					close(started)
+					<-release
+					if tc.panics {
+						panic("boom")
+					}
+					return "first", nil
+				})
+			}()
+			<-started
+
+			done := make(chan struct{})
+			var (
+				result   interface{}
+				replayed bool
+			)
+			go func() {
+				defer close(done)
+				result, replayed, _ = s.Do("key", nil, func() (interface{}, error) {
+					runs.Add(1)
+					return "second", nil
+				})
+			}()
+			select {
+			case <-done:
+				t.Fatal("duplicate ran before the first call finished")
+			case <-time.After(20 * time.Millisecond):
+			}
+			close(release)
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("duplicate still waiting")
+			}
+
+			if replayed != tc.wantReplayed {
+				t.Errorf("replayed = %v, want %v", replayed, tc.wantReplayed)
+			}
+			if want := map[bool]string{true: "first", false: "second"}[tc.wantReplayed]; result != want {
+				t.Errorf("result = %v, want %v", result, want)
+			}
+			if want := map[bool]int32{true: 1, false: 2}[tc.wantReplayed]; runs.Load() != want {
+				t.Errorf("ran %d times, want %d", runs.Load(), want)
+			}
+		})
+	}
+}
+
+// TestForgets checks that results are dropped once expired, and the oldest
+// once the store is full
+func TestForgets(t *testing.T) {
+	ok := func() (interface{}, error) { return true, nil }
+	s := NewStore(time.Hour, 2)
+	for _, key := range []string{"a", "b", "c"} {
+		s.Do(key, nil, ok)
+	}
+	for key, want := range map[string]bool{"a": false, "b": true, "c": true} {
+		if _, remembered := s.entries[key]; remembered != want {
+			t.Errorf("full store remembers %s: %v, want %v", key, remembered, want)
+		}
+	}
+
+	s = NewStore(time.Millisecond, 10)
+	s.Do("a", nil, ok)
+	time.Sleep(5 * time.Millisecond)
+	if _, replayed, _ := s.Do("b", nil, ok); replayed {
+		t.Error("new key replayed")
+	}
+	if _, remembered := s.entries["a"]; remembered {
+		t.Error("expired result still held")
+	}
+}
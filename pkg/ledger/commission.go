@@ -0,0 +1,119 @@
+package ledger
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rule charges a commission on sales whose price is at least MinPrice cents.
+// The commission is RateBps basis points of the price plus a Flat fee in cents.
+type Rule struct {
+	MinPrice int64
+	RateBps  int64
+	Flat     int64
+}
+
+// Schedule is a set of tiered commission rules
+type Schedule []Rule
+
+// DefaultSchedule charges 10% below $1000 and 5% from $1000 upwards
+var DefaultSchedule = Schedule{
+	{MinPrice: 0, RateBps: 1000},
+	{MinPrice: 100000, RateBps: 500},
+}
+
+// Commission returns the commission due on a sale at the given price, using
+// the rule with the highest MinPrice not above the price. The commission
+// never exceeds the price.
+func (s Schedule) Commission(price int64) int64 {
+	var rule *Rule
+	for i := range s {
+		if s[i].MinPrice <= price && (rule == nil || s[i].MinPrice > rule.MinPrice) {
+			rule = &s[i]
+		}
+	}
+	if rule == nil {
+		return 0
+	}
+
+	commission := price*rule.RateBps/10000 + rule.Flat
+	if commission > price {
+		return price
+	}
+	if commission < 0 {
+		return 0
+	}
+	return commission
+}
+
+// ParseSchedule parses a comma separated list of tiers written as
+// "min=rate%[+flat]", with prices in currency units, e.g. "0=10%,1000=5%+2.50"
+func ParseSchedule(s string) (Schedule, error) {
+	var schedule Schedule
+	for _, tier := range strings.Split(s, ",") {
+		tier = strings.TrimSpace(tier)
+		if tier == "" {
+			continue
+		}
+
+		min, fee, ok := strings.Cut(tier, "=")
+		if !ok {
+			return nil, fmt.Errorf("commission tier %q: missing '='", tier)
+		}
+		rate, flat, _ := strings.Cut(fee, "+")
+
+		var rule Rule
+		var err error
+		if rule.MinPrice, err = parseMoney(min); err != nil {
+			return nil, fmt.Errorf("commission tier %q: %v", tier, err)
+		}
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(rate), "%"), 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return nil, fmt.Errorf("commission tier %q: invalid rate %q", tier, rate)
+		}
+		rule.RateBps = int64(math.Round(pct * 100))
+		if flat != "" {
+			if rule.Flat, err = parseMoney(flat); err != nil {
+				return nil, fmt.Errorf("commission tier %q: %v", tier, err)
+			}
+		}
+		schedule = append(schedule, rule)
+	}
+
+	if len(schedule) == 0 {
+		return nil, fmt.Errorf("commission schedule is empty")
+	}
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].MinPrice < schedule[j].MinPrice })
+	return schedule, nil
+}
+
+// String renders the schedule in the format accepted by ParseSchedule
+func (s Schedule) String() string {
+	tiers := make([]string, 0, len(s))
+	for _, r := range s {
+		tier := fmt.Sprintf("%s=%s%%", trimMoney(r.MinPrice), strconv.FormatFloat(float64(r.RateBps)/100, 'f', -1, 64))
+		if r.Flat != 0 {
+			tier += "+" + FormatCents(r.Flat)
+		}
+		tiers = append(tiers, tier)
+	}
+	return strings.Join(tiers, ",")
+}
+
+func parseMoney(s string) (int64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return int64(math.Round(v * 100)), nil
+}
+
+func trimMoney(c int64) string {
+	if c%100 == 0 {
+		return strconv.FormatInt(c/100, 10)
+	}
+	return FormatCents(c)
+}
@@ -0,0 +1,278 @@
+// Package ledger records every money movement of the auction house as a
+// balanced double-entry journal entry.
+//
+// Amounts are integer cents. A posting with a positive amount debits its
+// account and a negative amount credits it; the postings of every entry sum
+// to zero, so the sum of all account balances is always zero as well.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Account identifies a ledger account
+type Account string
+
+// House accounts
+const (
+	// HouseEscrow holds buyer payments until they are paid out
+	HouseEscrow Account = "house:escrow"
+	// HouseCommission accumulates the commission earned on sales
+	HouseCommission Account = "house:commission"
+)
+
+// UserAccount returns the account of a registered user
+func UserAccount(name string) Account {
+	return Account("user:" + name)
+}
+
+// User returns the name of the user owning a user account, or "" for a
+// house account
+func (a Account) User() string {
+	name, _ := strings.CutPrefix(string(a), "user:")
+	if name == string(a) {
+		return ""
+	}
+	return name
+}
+
+// ParseAccount turns a user name or a full account name into an Account
+func ParseAccount(s string) Account {
+	if strings.Contains(s, ":") {
+		return Account(s)
+	}
+	return UserAccount(s)
+}
+
+// Kind classifies a journal entry
+type Kind string
+
+// Entry kinds
+const (
+	KindPayment    Kind = "payment"
+	KindPayout     Kind = "payout"
+	KindCommission Kind = "commission"
+	KindRefund     Kind = "refund"
+)
+
+// Cents converts a price as carried by the API into integer cents
+func Cents(price float32) int64 {
+	return int64(math.Round(float64(price) * 100))
+}
+
+// Posting is one side of a journal entry
+type Posting struct {
+	Account Account
+	Amount  int64
+}
+
+// Entry is a balanced journal entry
+type Entry struct {
+	ID       int64
+	Time     time.Time
+	Kind     Kind
+	Product  string
+	Memo     string
+	Postings []Posting
+}
+
+// Errors returned by the ledger
+var (
+	ErrUnbalanced      = errors.New("ledger: entry does not balance")
+	ErrEmptyEntry      = errors.New("ledger: entry needs at least two postings")
+	ErrNothingToRefund = errors.New("ledger: nothing to refund")
+)
+
+// Ledger is an append-only journal safe for concurrent use
+type Ledger struct {
+	mu       sync.RWMutex
+	entries  []Entry
+	refunded map[string]bool
+	nextID   int64
+	now      func() time.Time
+}
+
+// New creates an empty ledger
+func New() *Ledger {
+	return &Ledger{
+		refunded: make(map[string]bool),
+		nextID:   1,
+		now:      time.Now,
+	}
+}
+
+// Post validates and appends a journal entry, returning it with its ID and time set
+func (l *Ledger) Post(e Entry) (Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.post(e)
+}
+
+func (l *Ledger) post(e Entry) (Entry, error) {
+	if len(e.Postings) < 2 {
+		return Entry{}, ErrEmptyEntry
+	}
+	var sum int64
+	for _, p := range e.Postings {
+		sum += p.Amount
+	}
+	if sum != 0 {
+		return Entry{}, fmt.Errorf("%w: off by %d cents", ErrUnbalanced, sum)
+	}
+
+	e.ID = l.nextID
+	l.nextID++
+	if e.Time.IsZero() {
+		e.Time = l.now()
+	}
+	e.Postings = append([]Posting(nil), e.Postings...)
+	l.entries = append(l.entries, e)
+	return e, nil
+}
+
+// Sale is a sale to be booked. Commission is the house's cut of Price, as
+// worked out by a Schedule when the sale was made.
+type Sale struct {
+	Product    string
+	Buyer      string
+	Seller     string
+	Price      int64
+	Commission int64
+	// Time of the sale; zero means now
+	Time time.Time
+}
+
+// RecordSale books a sale: the buyer pays into escrow, the house takes its
+// commission and the seller is paid the remainder
+func (l *Ledger) RecordSale(sale Sale) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	product, buyer, seller := sale.Product, sale.Buyer, sale.Seller
+	price, commission := sale.Price, sale.Commission
+	payout := price - commission
+
+	drafts := []Entry{
+		{
+			Kind:    KindPayment,
+			Product: product,
+			Memo:    fmt.Sprintf("%s pays for %s", buyer, product),
+			Postings: []Posting{
+				{Account: HouseEscrow, Amount: price},
+				{Account: UserAccount(buyer), Amount: -price},
+			},
+		},
+		{
+			Kind:    KindCommission,
+			Product: product,
+			Memo:    fmt.Sprintf("Commission on %s", product),
+			Postings: []Posting{
+				{Account: HouseCommission, Amount: commission},
+				{Account: HouseEscrow, Amount: -commission},
+			},
+		},
+		{
+			Kind:    KindPayout,
+			Product: product,
+			Memo:    fmt.Sprintf("Payout to %s for %s", seller, product),
+			Postings: []Posting{
+				{Account: UserAccount(seller), Amount: payout},
+				{Account: HouseEscrow, Amount: -payout},
+			},
+		},
+	}
+
+	posted := make([]Entry, 0, len(drafts))
+	for _, d := range drafts {
+		if d.Postings[0].Amount == 0 {
+			continue
+		}
+		d.Time = sale.Time
+		e, err := l.post(d)
+		if err != nil {
+			return posted, err
+		}
+		posted = append(posted, e)
+	}
+	delete(l.refunded, product)
+	return posted, nil
+}
+
+// RefundSale reverses every payment, commission and payout booked for a
+// product since it was last refunded in a single refund entry, dated at
+// (zero means now)
+func (l *Ledger) RefundSale(product, memo string, at time.Time) (Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.refunded[product] {
+		return Entry{}, ErrNothingToRefund
+	}
+
+	net := make(map[Account]int64)
+	for _, e := range l.entries {
+		if e.Product != product {
+			continue
+		}
+		switch e.Kind {
+		case KindPayment, KindCommission, KindPayout:
+			for _, p := range e.Postings {
+				net[p.Account] += p.Amount
+			}
+		case KindRefund:
+			// An earlier sale, already reversed
+			clear(net)
+		}
+	}
+
+	postings := make([]Posting, 0, len(net))
+	for acct, amount := range net {
+		if amount != 0 {
+			postings = append(postings, Posting{Account: acct, Amount: -amount})
+		}
+	}
+	if len(postings) == 0 {
+		return Entry{}, ErrNothingToRefund
+	}
+	sort.Slice(postings, func(i, j int) bool { return postings[i].Account < postings[j].Account })
+
+	e, err := l.post(Entry{Kind: KindRefund, Product: product, Memo: memo, Time: at, Postings: postings})
+	if err != nil {
+		return Entry{}, err
+	}
+	l.refunded[product] = true
+	return e, nil
+}
+
+// Balance returns the current balance of an account
+func (l *Ledger) Balance(acct Account) int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var balance int64
+	for _, e := range l.entries {
+		for _, p := range e.Postings {
+			if p.Account == acct {
+				balance += p.Amount
+			}
+		}
+	}
+	return balance
+}
+
+// Entries returns a copy of the whole journal in posting order
+func (l *Ledger) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]Entry, len(l.entries))
+	copy(entries, l.entries)
+	return entries
+}
@@ -0,0 +1,159 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// checkBalanced fails the test unless every entry's postings, and so every
+// account's balance, sum to zero
+func checkBalanced(t *testing.T, l *Ledger) {
+	t.Helper()
+	total := make(map[Account]int64)
+	for _, e := range l.Entries() {
+		var sum int64
+		for _, p := range e.Postings {
+			sum += p.Amount
+			total[p.Account] += p.Amount
+		}
+		if sum != 0 {
+			t.Errorf("entry %d (%s) is off by %d cents", e.ID, e.Kind, sum)
+		}
+	}
+	var sum int64
+	for acct, balance := range total {
+		if got := l.Balance(acct); got != balance {
+			t.Errorf("Balance(%s) = %d, postings sum to %d", acct, got, balance)
+		}
+		sum += balance
+	}
+	if sum != 0 {
+		t.Errorf("balances sum to %d", sum)
+	}
+}
+
+func TestPost(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		postings []Posting
+		want     error
+	}{
+		{"balanced", []Posting{{"a", 100}, {"b", -60}, {"c", -40}}, nil},
+		{"unbalanced", []Posting{{"a", 100}, {"b", -99}}, ErrUnbalanced},
+		{"one posting", []Posting{{"a", 0}}, ErrEmptyEntry},
+		{"none", nil, ErrEmptyEntry},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := New()
+			e, err := l.Post(Entry{Kind: KindPayment, Postings: tc.postings})
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Post = %v, want %v", err, tc.want)
+			}
+			if err != nil {
+				if len(l.Entries()) != 0 {
+					t.Error("rejected entry was kept")
+				}
+				return
+			}
+			if e.ID != 1 || e.Time.IsZero() {
+				t.Errorf("posted entry %d at %v", e.ID, e.Time)
+			}
+			checkBalanced(t, l)
+		})
+	}
+}
+
+func TestRecordSale(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		price       int64
+		wantEntries int
+	}{
+		{"with commission", 15000, 3},
+		{"commission only", 50, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := New()
+			commission := DefaultSchedule.Commission(tc.price)
+			if tc.price < 100 {
+				// The house takes it all
+				commission = tc.price
+			}
+			entries, err := l.RecordSale(Sale{Product: "Laptop", Buyer: "Alice", Seller: "Bob", Price: tc.price, Commission: commission})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != tc.wantEntries {
+				t.Errorf("booked %d entries, want %d", len(entries), tc.wantEntries)
+			}
+			checkBalanced(t, l)
+			for acct, want := range map[Account]int64{
+				UserAccount("Alice"): -tc.price,
+				UserAccount("Bob"):   tc.price - commission,
+				HouseCommission:      commission,
+				HouseEscrow:          0,
+			} {
+				if got := l.Balance(acct); got != want {
+					t.Errorf("Balance(%s) = %d, want %d", acct, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRefundSale(t *testing.T) {
+	l := New()
+	sold := time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC)
+	for _, sale := range []Sale{
+		{Product: "Laptop", Buyer: "Alice", Seller: "Bob", Price: 15000, Commission: 1500, Time: sold},
+		{Product: "Phone", Buyer: "Alice", Seller: "Bob", Price: 5000, Commission: 500, Time: sold},
+	} {
+		if _, err := l.RecordSale(sale); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed := sold.Add(time.Hour)
+	refund, err := l.RefundSale("Laptop", "Refund for removed listing Laptop", removed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.Kind != KindRefund || !refund.Time.Equal(removed) {
+		t.Errorf("refund is %s at %v", refund.Kind, refund.Time)
+	}
+	checkBalanced(t, l)
+	// Only the phone's sale is left standing
+	for acct, want := range map[Account]int64{
+		UserAccount("Alice"): -5000,
+		UserAccount("Bob"):   4500,
+		HouseCommission:      500,
+		HouseEscrow:          0,
+	} {
+		if got := l.Balance(acct); got != want {
+			t.Errorf("after refund Balance(%s) = %d, want %d", acct, got, want)
+		}
+	}
+
+	if _, err := l.RefundSale("Laptop", "again", time.Time{}); !errors.Is(err, ErrNothingToRefund) {
+		t.Errorf("second refund = %v, want ErrNothingToRefund", err)
+	}
+	if _, err := l.RefundSale("Tablet", "never sold", time.Time{}); !errors.Is(err, ErrNothingToRefund) {
+		t.Errorf("refund of an unsold product = %v, want ErrNothingToRefund", err)
+	}
+
+	// Sold again, the product can be refunded again, for the new sale only
+	if _, err := l.RecordSale(Sale{Product: "Laptop", Buyer: "Carol", Seller: "Bob", Price: 20000, Commission: 2000}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.RefundSale("Laptop", "removed again", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	checkBalanced(t, l)
+	if got := l.Balance(UserAccount("Carol")); got != 0 {
+		t.Errorf("Carol's balance after her refund = %d", got)
+	}
+	if got := l.Balance(UserAccount("Alice")); got != -5000 {
+		t.Errorf("Alice's balance = %d, want -5000", got)
+	}
+}
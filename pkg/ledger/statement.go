@@ -0,0 +1,91 @@
+package ledger
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Line is one movement on an account statement
+type Line struct {
+	EntryID int64
+	Time    time.Time
+	Kind    Kind
+	Product string
+	Memo    string
+	Amount  int64
+	Balance int64
+}
+
+// Statement lists the movements of one account with a running balance
+type Statement struct {
+	Account Account
+	Lines   []Line
+	Balance int64
+}
+
+// Statement builds the statement of an account
+func (l *Ledger) Statement(acct Account) Statement {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	st := Statement{Account: acct}
+	for _, e := range l.entries {
+		for _, p := range e.Postings {
+			if p.Account != acct {
+				continue
+			}
+			st.Balance += p.Amount
+			st.Lines = append(st.Lines, Line{
+				EntryID: e.ID,
+				Time:    e.Time,
+				Kind:    e.Kind,
+				Product: e.Product,
+				Memo:    e.Memo,
+				Amount:  p.Amount,
+				Balance: st.Balance,
+			})
+		}
+	}
+	return st
+}
+
+// WriteCSV exports the statement as CSV with one row per line
+func (st Statement) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"entry_id", "time", "account", "kind", "product", "memo", "amount", "balance"}); err != nil {
+		return err
+	}
+	for _, line := range st.Lines {
+		record := []string{
+			strconv.FormatInt(line.EntryID, 10),
+			line.Time.UTC().Format(time.RFC3339),
+			string(st.Account),
+			string(line.Kind),
+			line.Product,
+			line.Memo,
+			FormatCents(line.Amount),
+			FormatCents(line.Balance),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// FormatCents renders an amount of cents as a decimal string such as "-12.50"
+func FormatCents(c int64) string {
+	sign := ""
+	if c < 0 {
+		sign = "-"
+		c = -c
+	}
+	cents := strconv.FormatInt(c%100, 10)
+	if len(cents) == 1 {
+		cents = "0" + cents
+	}
+	return sign + strconv.FormatInt(c/100, 10) + "." + cents
+}
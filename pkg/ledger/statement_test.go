@@ -0,0 +1,51 @@
+package ledger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatementCSV(t *testing.T) {
+	l := New()
+	sold := time.Date(2026, 10, 18, 14, 3, 12, 0, time.FixedZone("CEST", 2*60*60))
+	if _, err := l.RecordSale(Sale{Product: "Laptop, 15\"", Buyer: "Alice", Seller: "Bob", Price: 15005, Commission: 1500, Time: sold}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.RefundSale("Laptop, 15\"", "Refund", sold.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	st := l.Statement(UserAccount("Bob"))
+	if st.Balance != 0 || len(st.Lines) != 2 {
+		t.Fatalf("statement %+v", st)
+	}
+	var out strings.Builder
+	if err := st.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+	// Times are in UTC, amounts in currency units, and fields with commas
+	// or quotes are quoted
+	want := `entry_id,time,account,kind,product,memo,amount,balance
+3,2026-10-18T12:03:12Z,user:Bob,payout,"Laptop, 15""","Payout to Bob for Laptop, 15""",135.05,135.05
+4,2026-10-18T13:03:12Z,user:Bob,refund,"Laptop, 15""",Refund,-135.05,0.00
+`
+	if out.String() != want {
+		t.Errorf("CSV:\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	for c, want := range map[int64]string{
+		0:     "0.00",
+		5:     "0.05",
+		-5:    "-0.05",
+		1250:  "12.50",
+		-1250: "-12.50",
+		100:   "1.00",
+	} {
+		if got := FormatCents(c); got != want {
+			t.Errorf("FormatCents(%d) = %q, want %q", c, got, want)
+		}
+	}
+}
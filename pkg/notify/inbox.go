@@ -0,0 +1,151 @@
+// Package notify keeps a per-user inbox of auction notifications in memory
+// and fans new notifications out to live subscribers. The inbox is rebuilt
+// from whatever raised the notifications, such as an event log.
+package notify
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Kind classifies a notification
+type Kind string
+
+// Notification kinds
+const (
+	KindOutbid       Kind = "outbid"
+	KindWon          Kind = "won"
+	KindLost         Kind = "lost"
+	KindItemSold     Kind = "item_sold"
+	KindListingEnded Kind = "listing_ended"
+	KindWatchedBid   Kind = "watched_bid"
+)
+
+// Notification is a message delivered to one user
+type Notification struct {
+	ID      int64     `json:"id"`
+	User    string    `json:"user"`
+	Kind    Kind      `json:"kind"`
+	Product string    `json:"product"`
+	Message string    `json:"message"`
+	Amount  float32   `json:"amount"`
+	Time    time.Time `json:"time"`
+	Read    bool      `json:"read"`
+}
+
+// ErrNotFound is returned when acknowledging an unknown notification
+var ErrNotFound = errors.New("notify: notification not found")
+
+// subscriberBuffer is how many notifications a slow subscriber may lag
+// behind before new ones are dropped for it; they stay in the inbox
+const subscriberBuffer = 16
+
+// Inbox stores notifications per user
+type Inbox struct {
+	mu          sync.Mutex
+	nextID      int64
+	byUser      map[string][]*Notification
+	subscribers map[string]map[chan Notification]struct{}
+}
+
+// NewInbox creates an empty inbox
+func NewInbox() *Inbox {
+	return &Inbox{
+		nextID:      1,
+		byUser:      make(map[string][]*Notification),
+		subscribers: make(map[string]map[chan Notification]struct{}),
+	}
+}
+
+// Push stores a notification raised at t for a user and delivers it to the
+// user's subscribers
+func (in *Inbox) Push(user string, kind Kind, product, message string, amount float32, t time.Time) Notification {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	n := &Notification{
+		ID:      in.nextID,
+		User:    user,
+		Kind:    kind,
+		Product: product,
+		Message: message,
+		Amount:  amount,
+		Time:    t,
+	}
+	in.nextID++
+	in.byUser[user] = append(in.byUser[user], n)
+
+	for ch := range in.subscribers[user] {
+		select {
+		case ch <- *n:
+		default:
+		}
+	}
+	return *n
+}
+
+// List returns a user's notifications, newest first, and the unread count
+func (in *Inbox) List(user string, unreadOnly bool) ([]Notification, int) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	all := in.byUser[user]
+	list := make([]Notification, 0, len(all))
+	unread := 0
+	for i := len(all) - 1; i >= 0; i-- {
+		n := all[i]
+		if !n.Read {
+			unread++
+		}
+		if unreadOnly && n.Read {
+			continue
+		}
+		list = append(list, *n)
+	}
+	return list, unread
+}
+
+// Ack marks one of a user's notifications as read. An id of zero marks
+// all of them.
+func (in *Inbox) Ack(user string, id int64) error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	found := false
+	for _, n := range in.byUser[user] {
+		if id == 0 || n.ID == id {
+			n.Read = true
+			found = true
+		}
+	}
+	if !found && id != 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Subscribe returns a channel receiving the user's new notifications and a
+// function that cancels the subscription
+func (in *Inbox) Subscribe(user string) (<-chan Notification, func()) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	ch := make(chan Notification, subscriberBuffer)
+	if in.subscribers[user] == nil {
+		in.subscribers[user] = make(map[chan Notification]struct{})
+	}
+	in.subscribers[user][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			in.mu.Lock()
+			defer in.mu.Unlock()
+			delete(in.subscribers[user], ch)
+			if len(in.subscribers[user]) == 0 {
+				delete(in.subscribers, user)
+			}
+		})
+	}
+}
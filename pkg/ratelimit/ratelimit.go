@@ -0,0 +1,236 @@
+// Package ratelimit throttles callers with token buckets. Every caller key,
+// such as a user name or an IP address, gets its own bucket per method.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit allows Rate calls per second on average and bursts of up to Burst
+// calls at once
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the limit lets every call through
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseLimit parses a limit such as "5/s", "30/m:10" or "100/h". The burst
+// after the colon defaults to the number of calls per unit; "off" disables
+// limiting.
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "off" {
+		return Limit{}, nil
+	}
+
+	spec, burstStr, hasBurst := strings.Cut(s, ":")
+	countStr, unit, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("limit %q: want calls/unit[:burst]", s)
+	}
+	per, ok := units[unit]
+	if !ok {
+		return Limit{}, fmt.Errorf("limit %q: unit must be s, m or h", s)
+	}
+	count, err := strconv.ParseFloat(countStr, 64)
+	if err != nil || count <= 0 {
+		return Limit{}, fmt.Errorf("limit %q: bad number of calls", s)
+	}
+
+	burst := int(math.Ceil(count))
+	if hasBurst {
+		burst, err = strconv.Atoi(burstStr)
+		if err != nil || burst < 1 {
+			return Limit{}, fmt.Errorf("limit %q: bad burst", s)
+		}
+	}
+	return Limit{Rate: count / per.Seconds(), Burst: burst}, nil
+}
+
+// String formats the limit the way ParseLimit reads it
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "off"
+	}
+	for _, u := range []string{"s", "m", "h"} {
+		count := l.Rate * units[u].Seconds()
+		if count >= 1 && count == math.Trunc(count) {
+			return fmt.Sprintf("%g/%s:%d", count, u, l.Burst)
+		}
+	}
+	return fmt.Sprintf("%g/s:%d", l.Rate, l.Burst)
+}
+
+// Default is the Limits key of the limit for methods not listed explicitly
+const Default = "*"
+
+// Limits maps method names to their limit
+type Limits map[string]Limit
+
+// ParseLimits parses a comma separated list of "method=limit" pairs, e.g.
+// "PlaceBid=5/s:10,RegisterUser=10/m,*=50/s"
+func ParseLimits(s string) (Limits, error) {
+	limits := Limits{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		method, spec, ok := strings.Cut(pair, "=")
+		if !ok || method == "" {
+			return nil, fmt.Errorf("rate limit %q: want method=limit", pair)
+		}
+		l, err := ParseLimit(spec)
+		if err != nil {
+			return nil, err
+		}
+		limits[method] = l
+	}
+	return limits, nil
+}
+
+// For returns the limit of a method, falling back to the default
+func (ls Limits) For(method string) Limit {
+	if l, ok := ls[method]; ok {
+		return l
+	}
+	return ls[Default]
+}
+
+// String formats the limits the way ParseLimits reads them
+func (ls Limits) String() string {
+	methods := make([]string, 0, len(ls))
+	for m := range ls {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+
+	pairs := make([]string, 0, len(methods))
+	for _, m := range methods {
+		pairs = append(pairs, m+"="+ls[m].String())
+	}
+	return strings.Join(pairs, ",")
+}
+
+// bucket holds the tokens left for one key and method
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens earned since the bucket was last used
+func (b *bucket) refill(l Limit, now time.Time) {
+	b.tokens = math.Min(float64(l.Burst), b.tokens+now.Sub(b.last).Seconds()*l.Rate)
+	b.last = now
+}
+
+// sweepInterval is how often buckets that have filled up again are dropped
+const sweepInterval = time.Minute
+
+// Limiter decides whether calls may proceed
+type Limiter struct {
+	limits Limits
+	now    func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New creates a limiter enforcing limits
+func New(limits Limits) *Limiter {
+	return &Limiter{
+		limits:    limits,
+		now:       time.Now,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token for method from the bucket of every key. The call is
+// allowed only if all of them have one left; otherwise nothing is taken and
+// Allow returns how long to wait before the call would succeed. Empty keys
+// are ignored.
+func (l *Limiter) Allow(method string, keys ...string) (bool, time.Duration) {
+	limit := l.limits.For(method)
+	if limit.Unlimited() {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	denied := false
+	var wait time.Duration
+	held := make([]*bucket, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		id := method + "\x00" + key
+		b, ok := l.buckets[id]
+		if !ok {
+			b = &bucket{tokens: float64(limit.Burst), last: now}
+			l.buckets[id] = b
+		}
+		b.refill(limit, now)
+		if b.tokens < 1 {
+			denied = true
+			need := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+			if need > wait {
+				wait = need
+			}
+		}
+		held = append(held, b)
+	}
+
+	if denied {
+		return false, wait
+	}
+	for _, b := range held {
+		b.tokens--
+	}
+	return true, 0
+}
+
+// sweep forgets buckets that have been idle long enough to be full again,
+// which is the same as never having been used; callers hold l.mu
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for id, b := range l.buckets {
+		method, _, _ := strings.Cut(id, "\x00")
+		limit := l.limits.For(method)
+		if b.tokens+now.Sub(b.last).Seconds()*limit.Rate >= float64(limit.Burst) {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// RetryAfterSeconds rounds a wait up to the whole seconds used by
+// Retry-After headers
+func RetryAfterSeconds(wait time.Duration) int {
+	return int(math.Ceil(wait.Seconds()))
+}
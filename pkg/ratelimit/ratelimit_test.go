@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// clock is a time the test moves by hand
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+// newTestLimiter returns a limiter of 2 calls per second in bursts of up
+// to 3, on a clock the test moves
+func newTestLimiter(t *testing.T) (*Limiter, *clock) {
+	t.Helper()
+	limits, err := ParseLimits("Bid=2/s:3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &clock{t: time.Unix(1000, 0)}
+	l := New(limits)
+	l.now = c.now
+	return l, c
+}
+
+func TestBurstAndRefill(t *testing.T) {
+	l, c := newTestLimiter(t)
+	for _, step := range []struct {
+		after   time.Duration
+		allowed bool
+		wait    time.Duration
+	}{
+		// A burst of three goes through at once, the fourth waits for
+		// half a second's token
+		{0, true, 0},
+		{0, true, 0},
+		{0, true, 0},
+		{0, false, 500 * time.Millisecond},
+		{200 * time.Millisecond, false, 300 * time.Millisecond},
+		{300 * time.Millisecond, true, 0},
+		{0, false, 500 * time.Millisecond},
+		// Idle for long, the bucket fills up to the burst and no further
+		{time.Minute, true, 0},
+		{0, true, 0},
+		{0, true, 0},
+		{0, false, 500 * time.Millisecond},
+	} {
+		c.t = c.t.Add(step.after)
+		allowed, wait := l.Allow("Bid", "alice")
+		if allowed != step.allowed || wait != step.wait {
+			t.Fatalf("at %v: Allow = %v, %v; want %v, %v", c.t.Sub(time.Unix(1000, 0)), allowed, wait, step.allowed, step.wait)
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	l, _ := newTestLimiter(t)
+	for range 3 {
+		if ok, _ := l.Allow("Bid", "addr:1", "user:alice"); !ok {
+			t.Fatal("burst denied")
+		}
+	}
+	// alice's bucket is empty, so her calls are denied from anywhere,
+	// without spending the other address's tokens
+	if ok, _ := l.Allow("Bid", "addr:2", "user:alice"); ok {
+		t.Error("user over the limit allowed from another address")
+	}
+	for range 3 {
+		if ok, _ := l.Allow("Bid", "addr:2", "user:bob"); !ok {
+			t.Fatal("other user's burst denied")
+		}
+	}
+	// Methods without a limit of their own and no default are unlimited,
+	// and empty keys are ignored
+	if ok, _ := l.Allow("Other", "user:alice"); !ok {
+		t.Error("unlimited method denied")
+	}
+	if ok, _ := l.Allow("Bid", "", "addr:3"); !ok {
+		t.Error("empty key counted")
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want Limit
+		ok   bool
+	}{
+		{"5/s", Limit{Rate: 5, Burst: 5}, true},
+		{"30/m:10", Limit{Rate: 0.5, Burst: 10}, true},
+		{"off", Limit{}, true},
+		{"5", Limit{}, false},
+		{"5/d", Limit{}, false},
+		{"0/s", Limit{}, false},
+		{"5/s:0", Limit{}, false},
+	} {
+		got, err := ParseLimit(tc.in)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("ParseLimit(%q) = %v, %v", tc.in, got, err)
+		}
+		if tc.ok {
+			if back, _ := ParseLimit(got.String()); back != got {
+				t.Errorf("%q formats as %q, read back as %v", tc.in, got.String(), back)
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package rbac implements role-based access control: roles grant
+// permissions, and a Store records which roles each principal holds.
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Role is a named set of permissions
+type Role string
+
+// Roles known to the auction
+const (
+	RoleBuyer          Role = "buyer"
+	RoleSeller         Role = "seller"
+	RoleVerifiedSeller Role = "verified_seller"
+	RoleModerator      Role = "moderator"
+	RoleAdmin          Role = "admin"
+)
+
+// Permission allows one kind of action
+type Permission string
+
+// Permissions checked by the auction server
+const (
+	PermBid            Permission = "bid"
+	PermWatch          Permission = "watch"
+	PermProfile        Permission = "profile"
+	PermList           Permission = "list"
+	PermListHighValue  Permission = "list.high_value"
+	PermModerate       Permission = "moderate"
+	PermInspect        Permission = "inspect"
+	PermManageWebhooks Permission = "webhooks.manage"
+	PermManageRoles    Permission = "roles.manage"
+)
+
+// Policy maps each role to the permissions it grants
+type Policy map[Role][]Permission
+
+// DefaultPolicy is the auction's standard role model. Every role includes
+// the permissions of the roles it builds on.
+var DefaultPolicy = Policy{
+	RoleBuyer:          {PermBid, PermWatch, PermProfile},
+	RoleSeller:         {PermList, PermProfile},
+	RoleVerifiedSeller: {PermList, PermListHighValue, PermProfile},
+	RoleModerator:      {PermModerate, PermInspect},
+	RoleAdmin: {
+		PermBid, PermWatch, PermProfile, PermList, PermListHighValue,
+		PermModerate, PermInspect, PermManageWebhooks, PermManageRoles,
+	},
+}
+
+// DefaultRoles are granted to every newly registered user
+var DefaultRoles = []Role{RoleBuyer, RoleSeller}
+
+// Known reports whether the policy defines a role
+func (p Policy) Known(r Role) bool {
+	_, ok := p[r]
+	return ok
+}
+
+// Allows reports whether any of the roles grants the permission
+func (p Policy) Allows(roles []Role, perm Permission) bool {
+	for _, r := range roles {
+		for _, granted := range p[r] {
+			if granted == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ErrUnknownRole is returned when granting a role the policy does not define
+var ErrUnknownRole = errors.New("rbac: unknown role")
+
+// Store records the roles held by each principal in memory. Whoever owns
+// the store rebuilds it, such as from an event log.
+type Store struct {
+	mu     sync.RWMutex
+	policy Policy
+	roles  map[string]map[Role]bool
+}
+
+// NewStore creates an empty store for a policy
+func NewStore(policy Policy) *Store {
+	return &Store{
+		policy: policy,
+		roles:  make(map[string]map[Role]bool),
+	}
+}
+
+// Policy returns the policy the store's roles are evaluated against
+func (s *Store) Policy() Policy {
+	return s.policy
+}
+
+// Roles returns the roles of a principal, sorted by name
+func (s *Store) Roles(name string) []Role {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	roles := make([]Role, 0, len(s.roles[name]))
+	for r := range s.roles[name] {
+		roles = append(roles, r)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+	return roles
+}
+
+// Has reports whether a principal holds a role
+func (s *Store) Has(name string, r Role) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.roles[name][r]
+}
+
+// Can reports whether a principal holds a role granting the permission
+func (s *Store) Can(name string, perm Permission) bool {
+	return s.policy.Allows(s.Roles(name), perm)
+}
+
+// Grant gives a principal roles, returning whether any was new
+func (s *Store) Grant(name string, roles ...Role) (bool, error) {
+	for _, r := range roles {
+		if !s.policy.Known(r) {
+			return false, fmt.Errorf("%w %q", ErrUnknownRole, r)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.roles[name] == nil {
+		s.roles[name] = make(map[Role]bool)
+	}
+	changed := false
+	for _, r := range roles {
+		if !s.roles[name][r] {
+			s.roles[name][r] = true
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// Revoke removes roles from a principal, returning whether any was held
+func (s *Store) Revoke(name string, roles ...Role) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for _, r := range roles {
+		if s.roles[name][r] {
+			delete(s.roles[name], r)
+			changed = true
+		}
+	}
+	if len(s.roles[name]) == 0 {
+		delete(s.roles, name)
+	}
+	return changed
+}
@@ -0,0 +1,70 @@
+// Package tracing sets up OpenTelemetry tracing for the auction's servers.
+// Trace context travels in W3C traceparent headers, so a browser call can be
+// followed from the web server into the auction server.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exporters that Setup accepts
+const (
+	ExporterNone   = ""
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Setup traces the service named service, sending spans to exporter:
+// "stdout" prints them, "otlp" ships them over gRPC to the collector set in
+// the standard OTEL_EXPORTER_OTLP_* variables (localhost:4317 by default),
+// and "" only propagates trace context. The returned function flushes the
+// spans still buffered.
+func Setup(ctx context.Context, service, exporter string) (func(context.Context) error, error) {
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case ExporterNone:
+		otel.SetTextMapPropagator(Propagator())
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exp, err = stdouttrace.New()
+	case ExporterOTLP:
+		exp, err = otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, expected stdout or otlp", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := Install(service, sdktrace.NewBatchSpanProcessor(exp))
+	return provider.Shutdown, nil
+}
+
+// Install makes a provider sending spans to processor the global tracer
+// provider. Setup uses it with a batching exporter; with a synchronous
+// processor over an in-memory exporter, spans can be inspected as soon as
+// they end.
+func Install(service string, processor sdktrace.SpanProcessor) *sdktrace.TracerProvider {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(service))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(Propagator())
+	return provider
+}
+
+// Propagator carries trace context and baggage across processes
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
@@ -0,0 +1,396 @@
+// Package webhook delivers auction events to subscribed HTTP endpoints.
+//
+// Payloads are signed with HMAC-SHA256 (see Sign and Verify), failed
+// deliveries are retried with exponential backoff, and deliveries that keep
+// failing end up in a dead-letter list from which they can be replayed.
+// Subscriptions and dead letters can be kept in a file to survive restarts.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Subscription is an endpoint registered to receive events
+type Subscription struct {
+	ID        string
+	URL       string
+	Secret    string
+	Events    []string
+	CreatedAt time.Time
+}
+
+// wants reports whether the subscription receives events of the given type.
+// An empty event list subscribes to everything.
+func (s Subscription) wants(eventType string) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, e := range s.Events {
+		if e == eventType || e == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the JSON body posted to subscribers
+type Event struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// DeadLetter is a delivery that exhausted its retries
+type DeadLetter struct {
+	ID             string
+	SubscriptionID string
+	Event          Event
+	Attempts       int
+	LastError      string
+	FailedAt       time.Time
+}
+
+// Options configure a Dispatcher
+type Options struct {
+	// Client sends the HTTP requests; defaults to a client with a 5s timeout
+	Client *http.Client
+	// MaxAttempts per delivery before it is dead-lettered; defaults to 5
+	MaxAttempts int
+	// InitialBackoff before the first retry, doubled on every retry; defaults to 1s
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries; defaults to 1m
+	MaxBackoff time.Duration
+	// Concurrency limits simultaneous HTTP requests; defaults to 8
+	Concurrency int
+	// Path is the file subscriptions and dead letters are kept in; empty
+	// keeps them in memory only. It holds the signing secrets.
+	Path string
+}
+
+// Errors returned by the dispatcher
+var (
+	ErrInvalidURL = errors.New("webhook: URL must be absolute http or https")
+	ErrNotFound   = errors.New("webhook: not found")
+	ErrStopped    = errors.New("webhook: dispatcher stopped")
+)
+
+// Dispatcher fans events out to subscriptions
+type Dispatcher struct {
+	opts Options
+
+	mu            sync.Mutex
+	subscriptions map[string]Subscription
+	deadLetters   []DeadLetter
+	// saveErr is the last failure to write a dead letter to disk, reported
+	// by the next Publish
+	saveErr error
+
+	sem     chan struct{}
+	stop    chan struct{}
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// stored is the content of the file a dispatcher is kept in
+type stored struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+	DeadLetters   []DeadLetter   `json:"dead_letters"`
+}
+
+// NewDispatcher creates a dispatcher, filling in defaults for zero options
+// and loading the subscriptions and dead letters stored at opts.Path
+func NewDispatcher(opts Options) (*Dispatcher, error) {
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 5 * time.Second}
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = time.Minute
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+
+	d := &Dispatcher{
+		opts:          opts,
+		subscriptions: make(map[string]Subscription),
+		sem:           make(chan struct{}, opts.Concurrency),
+		stop:          make(chan struct{}),
+	}
+	if opts.Path == "" {
+		return d, nil
+	}
+
+	data, err := os.ReadFile(opts.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var st stored
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("webhook: %s: %w", opts.Path, err)
+	}
+	for _, sub := range st.Subscriptions {
+		d.subscriptions[sub.ID] = sub
+	}
+	d.deadLetters = st.DeadLetters
+	return d, nil
+}
+
+// Subscribe registers an endpoint for the given event types. An error other
+// than ErrInvalidURL means the subscription could not be saved; it is
+// registered all the same.
+func (d *Dispatcher) Subscribe(rawURL, secret string, events []string) (Subscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Subscription{}, ErrInvalidURL
+	}
+
+	sub := Subscription{
+		ID:        newID(),
+		URL:       rawURL,
+		Secret:    secret,
+		Events:    append([]string(nil), events...),
+		CreatedAt: time.Now(),
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions[sub.ID] = sub
+	return sub, d.save()
+}
+
+// Unsubscribe removes a subscription
+func (d *Dispatcher) Unsubscribe(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.subscriptions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(d.subscriptions, id)
+	return d.save()
+}
+
+// Subscriptions lists the registered subscriptions, oldest first
+func (d *Dispatcher) Subscriptions() []Subscription {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	subs := make([]Subscription, 0, len(d.subscriptions))
+	for _, s := range d.subscriptions {
+		subs = append(subs, s)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].CreatedAt.Before(subs[j].CreatedAt) })
+	return subs
+}
+
+// Publish delivers an event to every interested subscription in the
+// background. data is encoded as JSON. An error other than ErrStopped may
+// also mean that an earlier dead letter could not be saved; the event is
+// delivered all the same.
+func (d *Dispatcher) Publish(eventType string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	event := Event{ID: newID(), Type: eventType, Time: time.Now().UTC(), Data: raw}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return ErrStopped
+	}
+	for _, sub := range d.subscriptions {
+		if sub.wants(eventType) {
+			d.start(sub, event)
+		}
+	}
+	err, d.saveErr = d.saveErr, nil
+	return err
+}
+
+// DeadLetters lists the deliveries that exhausted their retries
+func (d *Dispatcher) DeadLetters() []DeadLetter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]DeadLetter(nil), d.deadLetters...)
+}
+
+// Replay removes a dead letter and delivers it again with a fresh retry budget
+func (d *Dispatcher) Replay(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return ErrStopped
+	}
+	for i, dl := range d.deadLetters {
+		if dl.ID != id {
+			continue
+		}
+		sub, ok := d.subscriptions[dl.SubscriptionID]
+		if !ok {
+			return fmt.Errorf("%w: subscription %s was deleted", ErrNotFound, dl.SubscriptionID)
+		}
+		d.deadLetters = append(d.deadLetters[:i], d.deadLetters[i+1:]...)
+		d.start(sub, dl.Event)
+		return d.save()
+	}
+	return ErrNotFound
+}
+
+// Stop cancels pending retries and waits for in-flight deliveries to finish.
+// Deliveries cut short are dead-lettered.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	if !d.stopped {
+		d.stopped = true
+		close(d.stop)
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+// start launches a delivery goroutine; callers hold d.mu
+func (d *Dispatcher) start(sub Subscription, event Event) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.deliver(sub, event)
+	}()
+}
+
+func (d *Dispatcher) deliver(sub Subscription, event Event) {
+	backoff := d.opts.InitialBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= d.opts.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-d.stop:
+				d.deadLetter(sub, event, attempt-1, fmt.Errorf("%w after: %v", ErrStopped, lastErr))
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > d.opts.MaxBackoff {
+				backoff = d.opts.MaxBackoff
+			}
+		}
+
+		if lastErr = d.send(sub, event); lastErr == nil {
+			return
+		}
+	}
+
+	d.deadLetter(sub, event, d.opts.MaxAttempts, lastErr)
+}
+
+// send performs a single signed POST of the event
+func (d *Dispatcher) send(sub Subscription, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	d.sem <- struct{}{}
+	defer func() { <-d.sem }()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderDelivery, event.ID)
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, time.Now(), body))
+
+	resp, err := d.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: %s responded %s", sub.URL, resp.Status)
+	}
+	return nil
+}
+
+func (d *Dispatcher) deadLetter(sub Subscription, event Event, attempts int, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.deadLetters = append(d.deadLetters, DeadLetter{
+		ID:             newID(),
+		SubscriptionID: sub.ID,
+		Event:          event,
+		Attempts:       attempts,
+		LastError:      err.Error(),
+		FailedAt:       time.Now(),
+	})
+	if err := d.save(); err != nil {
+		d.saveErr = err
+	}
+}
+
+// save writes the subscriptions and dead letters to disk; callers hold d.mu
+func (d *Dispatcher) save() error {
+	if d.opts.Path == "" {
+		return nil
+	}
+
+	st := stored{Subscriptions: make([]Subscription, 0, len(d.subscriptions)), DeadLetters: d.deadLetters}
+	for _, sub := range d.subscriptions {
+		st.Subscriptions = append(st.Subscriptions, sub)
+	}
+	sort.Slice(st.Subscriptions, func(i, j int) bool {
+		return st.Subscriptions[i].CreatedAt.Before(st.Subscriptions[j].CreatedAt)
+	})
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(d.opts.Path), 0o755); err != nil {
+		return err
+	}
+	tmp := d.opts.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, d.opts.Path)
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,185 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// delivery is a request received by an endpoint
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+// endpoint serves a webhook endpoint answering the first failures requests
+// with 500 and the rest with 204, passing on the requests it accepts
+func endpoint(t *testing.T, failures int32) (*httptest.Server, <-chan delivery, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	received := make(chan delivery, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		received <- delivery{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, received, &calls
+}
+
+func newTestDispatcher(t *testing.T, path string) *Dispatcher {
+	t.Helper()
+	d, err := NewDispatcher(Options{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Path:           path,
+	})
+	if err != nil {
+		t.Fatalf("NewDispatcher: %v", err)
+	}
+	t.Cleanup(d.Stop)
+	return d
+}
+
+func waitDelivery(t *testing.T, received <-chan delivery) delivery {
+	t.Helper()
+	select {
+	case got := <-received:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("no delivery")
+		return delivery{}
+	}
+}
+
+func waitDeadLetters(t *testing.T, d *Dispatcher, n int) []DeadLetter {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if dls := d.DeadLetters(); len(dls) >= n {
+			return dls
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("want %d dead letters, have %d", n, len(d.DeadLetters()))
+	return nil
+}
+
+func TestDeliverySigned(t *testing.T) {
+	srv, received, _ := endpoint(t, 0)
+	d := newTestDispatcher(t, "")
+	if _, err := d.Subscribe(srv.URL, "s3cret", []string{"bid.placed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Publish("user.registered", map[string]string{"name": "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Publish("bid.placed", map[string]interface{}{"buyer": "Alice", "amount": 150}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := waitDelivery(t, received)
+	if err := Verify("s3cret", got.header.Get(HeaderSignature), got.body, time.Minute); err != nil {
+		t.Errorf("signature: %v", err)
+	}
+	if typ := got.header.Get(HeaderEvent); typ != "bid.placed" {
+		t.Errorf("%s = %q, want bid.placed", HeaderEvent, typ)
+	}
+	var event Event
+	if err := json.Unmarshal(got.body, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != "bid.placed" || event.ID != got.header.Get(HeaderDelivery) {
+		t.Errorf("event %+v does not match its headers", event)
+	}
+	if string(event.Data) != `{"amount":150,"buyer":"Alice"}` {
+		t.Errorf("data = %s", event.Data)
+	}
+
+	select {
+	case extra := <-received:
+		t.Errorf("unsubscribed event delivered: %s", extra.body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDeliveryRetried(t *testing.T) {
+	srv, received, calls := endpoint(t, 2)
+	d := newTestDispatcher(t, "")
+	if _, err := d.Subscribe(srv.URL, "s3cret", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Publish("bid.placed", nil); err != nil {
+		t.Fatal(err)
+	}
+	waitDelivery(t, received)
+	if n := calls.Load(); n != 3 {
+		t.Errorf("endpoint called %d times, want 3", n)
+	}
+	if dls := d.DeadLetters(); len(dls) != 0 {
+		t.Errorf("dead letters = %+v, want none", dls)
+	}
+}
+
+func TestDeadLetterSavedAndReplayed(t *testing.T) {
+	srv, received, calls := endpoint(t, 3)
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	d := newTestDispatcher(t, path)
+	sub, err := d.Subscribe(srv.URL, "s3cret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Publish("auction.closed", map[string]string{"product": "Laptop"}); err != nil {
+		t.Fatal(err)
+	}
+	dl := waitDeadLetters(t, d, 1)[0]
+	if dl.SubscriptionID != sub.ID || dl.Attempts != 3 || dl.Event.Type != "auction.closed" {
+		t.Errorf("dead letter = %+v", dl)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("endpoint called %d times, want 3", n)
+	}
+	d.Stop()
+
+	// A restarted dispatcher still has both, and delivers the dead letter
+	// now that the endpoint has recovered
+	restarted := newTestDispatcher(t, path)
+	if subs := restarted.Subscriptions(); len(subs) != 1 || subs[0].ID != sub.ID || subs[0].Secret != "s3cret" {
+		t.Fatalf("subscriptions after restart = %+v", subs)
+	}
+	if dls := restarted.DeadLetters(); len(dls) != 1 || dls[0].ID != dl.ID {
+		t.Fatalf("dead letters after restart = %+v", dls)
+	}
+	if err := restarted.Replay(dl.ID); err != nil {
+		t.Fatal(err)
+	}
+	got := waitDelivery(t, received)
+	if id := got.header.Get(HeaderDelivery); id != dl.Event.ID {
+		t.Errorf("replayed delivery %s, want %s", id, dl.Event.ID)
+	}
+	restarted.Stop()
+
+	again := newTestDispatcher(t, path)
+	if dls := again.DeadLetters(); len(dls) != 0 {
+		t.Errorf("replayed dead letter still saved: %+v", dls)
+	}
+	if err := again.Unsubscribe(sub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if subs := newTestDispatcher(t, path).Subscriptions(); len(subs) != 0 {
+		t.Errorf("deleted subscription still saved: %+v", subs)
+	}
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set on every delivery
+const (
+	HeaderSignature = "X-Auction-Signature"
+	HeaderEvent     = "X-Auction-Event"
+	HeaderDelivery  = "X-Auction-Delivery"
+)
+
+// Errors returned by Verify
+var (
+	ErrBadSignature = errors.New("webhook: signature mismatch")
+	ErrMalformed    = errors.New("webhook: malformed signature header")
+	ErrExpired      = errors.New("webhook: signature timestamp outside tolerance")
+)
+
+// Sign computes the signature header for a payload sent at t. The HMAC-SHA256
+// covers "<unix seconds>.<body>" so a captured payload cannot be replayed
+// with a different timestamp.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + mac(secret, ts, body)
+}
+
+// Verify checks a signature header against the payload. Receivers should
+// reject payloads whose timestamp is further than tolerance from now; a zero
+// tolerance skips that check.
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return ErrMalformed
+		}
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+	if ts == "" || sig == "" {
+		return ErrMalformed
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrMalformed
+	}
+	if tolerance > 0 {
+		if d := time.Since(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+			return ErrExpired
+		}
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(mac(secret, ts, body))) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+func mac(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
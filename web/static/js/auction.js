@@ -2,13 +2,19 @@
 let currentUser = '';
 let isTyping = false;
 let typingTimer = null;
-let refreshInterval = null;
+let eventSource = null;
+let lastEventId = '';     // sequence number of the last auction event seen
+let catalogStale = false; // a change arrived while the user was typing
+let refreshTimer = null;
 let lastCatalogHash = '';
+let watchedProducts = new Set();
+let productVersions = {};  // product -> version shown in the catalog
 
 // Configuration
 const CONFIG = {
-    API_URL: 'http://localhost:8080',
-    REFRESH_INTERVAL: 5000,  // 5 seconds
+    API_URL: '',  // the web server serving this page
+    RECONNECT_DELAY: 3000,   // 3 seconds before reopening a failed stream
+    REFRESH_DEBOUNCE: 200,   // changes arriving together reload the catalog once
     TYPING_COOLDOWN: 2000,   // 2 seconds after typing stops
     MAX_BID_HISTORY: 10
 };
@@ -19,6 +25,27 @@ document.addEventListener('DOMContentLoaded', () => {
     console.log('Auction system initialized');
 });
 
+// New idempotency key for a mutating request; resending the same request
+// with the same key returns the original result instead of acting twice
+function newIdempotencyKey() {
+    if (window.crypto && crypto.randomUUID) {
+        return crypto.randomUUID();
+    }
+    return `${Date.now()}-${Math.random().toString(36).slice(2)}`;
+}
+
+// Message of a failed API call; the server answers with a Connect error,
+// {"code": "...", "message": "..."}, or plain text when it turned the request
+// away before the call, e.g. over the rate limit
+async function errorMessage(response) {
+    const text = await response.text();
+    try {
+        return JSON.parse(text).message || text;
+    } catch (err) {
+        return text;
+    }
+}
+
 // Initialize all event listeners
 function initializeEventListeners() {
     // Username input - Enter key to register
@@ -53,10 +80,7 @@ function handleInputChange(e) {
         updateRefreshStatus('paused');
         
         // Reset typing state after cooldown period
-        typingTimer = setTimeout(() => {
-            isTyping = false;
-            updateRefreshStatus('active');
-        }, CONFIG.TYPING_COOLDOWN);
+        typingTimer = setTimeout(stopTyping, CONFIG.TYPING_COOLDOWN);
     }
 }
 
@@ -65,13 +89,23 @@ function handleInputBlur(e) {
     if (e.target.tagName === 'INPUT' && e.target.type === 'number') {
         setTimeout(() => {
             if (!document.activeElement || document.activeElement.type !== 'number') {
-                isTyping = false;
-                updateRefreshStatus('active');
+                stopTyping();
             }
         }, 500);
     }
 }
 
+// Resume updates once the user stops typing, catching up on changes held
+// back meanwhile
+function stopTyping() {
+    isTyping = false;
+    updateRefreshStatus('active');
+    if (catalogStale) {
+        catalogStale = false;
+        refreshCatalog();
+    }
+}
+
 // Update refresh status indicator
 function updateRefreshStatus(status) {
     const indicator = document.querySelector('.status-indicator');
@@ -107,9 +141,10 @@ async function registerUser() {
 
             // Show add-product form and hide the "need to register" message
             setAddProductUIVisible(true);
+            await loadProfile();
             
             await loadCatalog();
-            startAutoRefresh();
+            subscribeEvents();
             
             showAlert(`Welcome, ${username}!`, 'success');
         } else {
@@ -121,6 +156,64 @@ async function registerUser() {
     }
 }
 
+// Load the current user's email preferences
+async function loadProfile() {
+    const section = document.getElementById('profileSection');
+    if (!currentUser || !section) return;
+
+    try {
+        const response = await fetch(`${CONFIG.API_URL}/auction.AuctionService/GetProfile`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({name: currentUser})
+        });
+
+        const data = await response.json();
+        if (data.found) {
+            document.getElementById('profileEmail').value = data.user.email;
+            document.getElementById('profileEmailEnabled').checked = data.user.email_enabled;
+        }
+        section.style.display = 'flex';
+    } catch (err) {
+        console.error('Error loading profile:', err);
+    }
+}
+
+// Save the current user's email preferences
+async function saveProfile() {
+    if (!currentUser) {
+        showAlert('Please register first', 'warning');
+        return;
+    }
+
+    try {
+        const response = await fetch(`${CONFIG.API_URL}/auction.AuctionService/UpdateProfile`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({
+                name: currentUser,
+                email: document.getElementById('profileEmail').value.trim(),
+                email_enabled: document.getElementById('profileEmailEnabled').checked
+            })
+        });
+
+        if (!response.ok) {
+            showAlert(await errorMessage(response), 'error');
+            return;
+        }
+
+        const data = await response.json();
+        showAlert(data.success ? 'Email preferences saved' : data.message, data.success ? 'success' : 'error');
+    } catch (err) {
+        console.error('Error saving profile:', err);
+        showAlert('Error saving preferences. Please try again.', 'error');
+    }
+}
+
 // Load catalog from server
 async function loadCatalog() {
     if (!currentUser) return;
@@ -136,9 +229,13 @@ async function loadCatalog() {
         
         const data = await response.json();
         const products = data.products || [];
+
+        // Watched products are listed first
+        await loadWatchlist();
+        products.sort((a, b) => watchedProducts.has(b.product) - watchedProducts.has(a.product));
         
         // Only update if catalog changed (prevents unnecessary DOM updates)
-        const catalogHash = JSON.stringify(products);
+        const catalogHash = JSON.stringify([products, [...watchedProducts]]);
         if (catalogHash !== lastCatalogHash) {
             displayProducts(products);
             lastCatalogHash = catalogHash;
@@ -146,12 +243,133 @@ async function loadCatalog() {
         
         // Update last refresh time
         updateLastRefreshTime();
+
+        await loadNotifications();
         
     } catch (err) {
         console.error('Error loading catalog:', err);
     }
 }
 
+// Load the names of the products the current user is watching
+async function loadWatchlist() {
+    try {
+        const response = await fetch(`${CONFIG.API_URL}/auction.AuctionService/ListWatchlist`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({user: currentUser})
+        });
+
+        const data = await response.json();
+        watchedProducts = new Set((data.products || []).map(p => p.product));
+    } catch (err) {
+        console.error('Error loading watchlist:', err);
+    }
+}
+
+// Watch or unwatch a product
+async function toggleWatch(productName) {
+    if (!currentUser) {
+        showAlert('Please register first', 'warning');
+        return;
+    }
+
+    const method = watchedProducts.has(productName) ? 'RemoveFromWatchlist' : 'AddToWatchlist';
+    try {
+        const response = await fetch(`${CONFIG.API_URL}/auction.AuctionService/${method}`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({
+                user: currentUser,
+                product: productName
+            })
+        });
+
+        if (!response.ok) {
+            showAlert(await errorMessage(response), 'error');
+            return;
+        }
+
+        const data = await response.json();
+        if (data.success) {
+            await loadCatalog();
+        } else {
+            showAlert(data.message, 'error');
+        }
+    } catch (err) {
+        console.error('Error updating watchlist:', err);
+        showAlert('Error updating watchlist. Please try again.', 'error');
+    }
+}
+
+// Load the current user's notifications
+async function loadNotifications() {
+    if (!currentUser) return;
+
+    try {
+        const response = await fetch(`${CONFIG.API_URL}/auction.AuctionService/ListNotifications`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({user: currentUser})
+        });
+
+        const data = await response.json();
+        displayNotifications(data.notifications || [], data.unread || 0);
+    } catch (err) {
+        console.error('Error loading notifications:', err);
+    }
+}
+
+// Display notifications, newest first
+function displayNotifications(notifications, unread) {
+    const container = document.getElementById('notifications');
+    const badge = document.getElementById('unreadCount');
+    if (badge) {
+        badge.textContent = unread > 0 ? unread : '';
+    }
+    if (!container) return;
+
+    if (notifications.length === 0) {
+        container.innerHTML = '<div class="empty-state">No notifications</div>';
+        return;
+    }
+
+    container.innerHTML = '';
+    notifications.slice(0, CONFIG.MAX_BID_HISTORY).forEach(n => {
+        const entry = document.createElement('div');
+        entry.className = `notification ${n.kind}${n.read ? '' : ' unread'}`;
+        entry.innerHTML = `
+            ${escapeHtml(n.message)}
+            <small>${new Date(n.time).toLocaleTimeString()}</small>
+        `;
+        container.appendChild(entry);
+    });
+}
+
+// Mark all of the current user's notifications as read
+async function markAllRead() {
+    if (!currentUser) return;
+
+    try {
+        await fetch(`${CONFIG.API_URL}/auction.AuctionService/AckNotification`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({user: currentUser, id: 0})
+        });
+        await loadNotifications();
+    } catch (err) {
+        console.error('Error acknowledging notifications:', err);
+    }
+}
+
 // Display products with input preservation
 function displayProducts(products) {
     const container = document.getElementById('products');
@@ -176,18 +394,46 @@ function displayProducts(products) {
     
     // Clear and rebuild
     container.innerHTML = '';
+    productVersions = {};
     
     products.forEach(product => {
+        productVersions[product.product] = product.version;
         const div = document.createElement('div');
-        div.className = 'product';
+        const watched = watchedProducts.has(product.product);
+        div.className = watched ? 'product watched' : 'product';
         const inputId = `bid-${product.product}`;
         const savedData = savedInputs[inputId] || { value: '' };
+        const watchButton = `
+            <button class="watch-button" onclick="toggleWatch('${escapeHtml(product.product)}')"
+                    title="${watched ? 'Remove from watchlist' : 'Add to watchlist'}">
+                ${watched ? '★' : '☆'} ${product.watchers || 0}
+            </button>`;
         
+        if (product.closed) {
+            div.className += ' closed';
+            div.innerHTML = `
+                ${watchButton}
+                <h3>📦 ${escapeHtml(product.product)}</h3>
+                <p><strong>Seller:</strong> ${escapeHtml(product.seller)}</p>
+                <p class="price">🔨 ${product.highest_bidder
+                    ? `Sold to ${escapeHtml(product.highest_bidder)} for $${product.current_price.toFixed(2)}`
+                    : 'Closed without bids'}</p>
+            `;
+            container.appendChild(div);
+            return;
+        }
+
+        const closeButton = product.seller === currentUser
+            ? `<button class="close-button" onclick="closeAuction('${escapeHtml(product.product)}')">Close Auction</button>`
+            : '';
+
         div.innerHTML = `
+            ${watchButton}
             <h3>📦 ${escapeHtml(product.product)}</h3>
             <p><strong>Seller:</strong> ${escapeHtml(product.seller)}</p>
             <p><strong>Starting Price:</strong> $${product.initial_price.toFixed(2)}</p>
-            <p class="price">💰 Current Bid: $${product.current_price.toFixed(2)}</p>
+            <p class="price">💰 Current Bid: $${product.current_price.toFixed(2)}${product.highest_bidder ? ` by ${escapeHtml(product.highest_bidder)}` : ''}</p>
+            ${closeButton}
             <div class="bid-section">
                 <input type="number" 
                        id="${inputId}" 
@@ -235,14 +481,28 @@ async function placeBid(productName) {
             method: 'POST',
             headers: {
                 'Content-Type': 'application/json',
+                'Idempotency-Key': newIdempotencyKey(),
             },
             body: JSON.stringify({
                 buyer: currentUser,
                 product: productName,
-                amount: amount
+                amount: amount,
+                // Bid against the price on screen; the server refuses the
+                // bid if someone else got there first
+                expected_version: productVersions[productName]
             })
         });
         
+        if (response.status === 409) {
+            showAlert(await errorMessage(response), 'warning');
+            await loadCatalog();
+            return;
+        }
+        if (!response.ok) {
+            showAlert(await errorMessage(response), 'error');
+            return;
+        }
+
         const data = await response.json();
         if (data.success) {
             addBidToHistory(currentUser, productName, amount);
@@ -261,6 +521,43 @@ async function placeBid(productName) {
     }
 }
 
+// Close one of the current user's auctions
+async function closeAuction(productName) {
+    if (!currentUser) {
+        showAlert('Please register first', 'warning');
+        return;
+    }
+
+    try {
+        const response = await fetch(`${CONFIG.API_URL}/auction.AuctionService/CloseAuction`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({
+                seller: currentUser,
+                product: productName
+            })
+        });
+
+        if (!response.ok) {
+            showAlert(await errorMessage(response), 'error');
+            return;
+        }
+
+        const data = await response.json();
+        if (data.success) {
+            await loadCatalog();
+            showAlert(data.message, 'success');
+        } else {
+            showAlert(data.message, 'error');
+        }
+    } catch (err) {
+        console.error('Error closing auction:', err);
+        showAlert('Error closing auction. Please try again.', 'error');
+    }
+}
+
 // Add bid to history
 function addBidToHistory(buyer, product, amount) {
     const history = document.getElementById('bidHistory');
@@ -291,24 +588,58 @@ function addBidToHistory(buyer, product, amount) {
     }
 }
 
-// Start auto-refresh
-function startAutoRefresh() {
-    // Clear any existing interval
-    if (refreshInterval) {
-        clearInterval(refreshInterval);
+// Subscribe to the auction's live events. The browser reconnects by itself
+// after a dropped connection, resuming after the last event it received;
+// if the server refused the stream, it is reopened here after a delay.
+function subscribeEvents() {
+    if (eventSource) {
+        eventSource.close();
     }
-    
-    // Start new interval
-    refreshInterval = setInterval(() => {
-        // Only refresh if:
-        // 1. User is logged in
-        // 2. User is not currently typing
-        if (currentUser && !isTyping) {
-            loadCatalog();
+
+    const params = new URLSearchParams({user: currentUser});
+    if (lastEventId) {
+        params.set('last_event_id', lastEventId);
+    }
+    eventSource = new EventSource(`${CONFIG.API_URL}/events?${params}`);
+
+    eventSource.onopen = () => {
+        updateRefreshStatus(isTyping ? 'paused' : 'active');
+        // Without an event to resume after, changes made while the stream
+        // was down are only seen by reloading
+        if (!lastEventId) {
+            refreshCatalog();
         }
-    }, CONFIG.REFRESH_INTERVAL);
-    
-    updateRefreshStatus('active');
+        loadNotifications();
+    };
+    eventSource.onerror = () => {
+        updateRefreshStatus('paused');
+        if (eventSource.readyState === EventSource.CLOSED) {
+            setTimeout(subscribeEvents, CONFIG.RECONNECT_DELAY);
+        }
+    };
+
+    eventSource.addEventListener('auction', (e) => {
+        lastEventId = e.lastEventId;
+        refreshCatalog();
+    });
+    eventSource.addEventListener('notification', () => {
+        loadNotifications();
+    });
+    // The events missed are gone, so everything is reloaded
+    eventSource.addEventListener('reset', () => {
+        lastEventId = '';
+        refreshCatalog();
+    });
+}
+
+// Reload the catalog after a change, unless the user is typing a bid
+function refreshCatalog() {
+    if (isTyping) {
+        catalogStale = true;
+        return;
+    }
+    clearTimeout(refreshTimer);
+    refreshTimer = setTimeout(loadCatalog, CONFIG.REFRESH_DEBOUNCE);
 }
 
 // Manual refresh button handler
@@ -377,6 +708,7 @@ async function addProduct() {
             method: 'POST',
             headers: {
                 'Content-Type': 'application/json',
+                'Idempotency-Key': newIdempotencyKey(),
             },
             body: JSON.stringify({
                 seller: currentUser,
@@ -385,6 +717,11 @@ async function addProduct() {
             })
         });
 
+        if (!response.ok) {
+            showAlert(await errorMessage(response), 'error');
+            return;
+        }
+
         const data = await response.json();
         if (data.success) {
             showAlert('Product added successfully!', 'success');
@@ -406,4 +743,8 @@ async function addProduct() {
 window.registerUser = registerUser;
 window.placeBid = placeBid;
 window.manualRefresh = manualRefresh;
-window.addProduct = addProduct; 
\ No newline at end of file
+window.addProduct = addProduct;
+window.closeAuction = closeAuction;
+window.markAllRead = markAllRead;
+window.saveProfile = saveProfile;
+window.toggleWatch = toggleWatch; 
\ No newline at end of file
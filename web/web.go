@@ -0,0 +1,10 @@
+// Package web holds the browser UI, embedded so that the web server is a
+// single binary
+package web
+
+import "embed"
+
+// FS holds the UI's page, in templates/, and the assets it loads, in static/
+//
+//go:embed templates static
+var FS embed.FS